@@ -0,0 +1,161 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// defaultRollbackTimeout bounds a rollback when BulkUpdateOptions sets none
+const defaultRollbackTimeout = 30 * time.Second
+
+// BulkUpdateOptions specifies the optional parameters to the BulkUpdateStatus method
+type BulkUpdateOptions struct {
+	// Rollback restores the previous status of every component that was
+	// updated when any other update fails, so the page is left as it was.
+	// The rollback still runs when the failure was the context being
+	// canceled or timing out. Failed updates are never kept in the client's
+	// Outbox, as replaying them later would undo the rollback.
+	Rollback bool
+
+	// RollbackTimeout bounds the rollback. Defaults to 30 seconds.
+	RollbackTimeout time.Duration
+}
+
+// BulkStatusResult is the outcome of BulkUpdateStatus for one component
+type BulkStatusResult struct {
+	ComponentID    string
+	PreviousStatus string
+	Status         string
+
+	// Component is the component as returned by its update, or nil if it
+	// was not updated.
+	Component *Component
+	Err       error
+
+	RolledBack  bool
+	RollbackErr error
+}
+
+// BulkUpdateStatus sets the status of many components of a given page id at
+// once, running at most Client.Concurrency updates at a time. statuses maps
+// component ids to their new status. The previous statuses are read with a
+// single ListComponents call before anything is changed. If any component
+// id is not on the page, nothing is changed.
+//
+// A result is returned for every component. If any update fails, the
+// returned error is a ComponentErrors listing them, along with any failed
+// rollbacks.
+func (s *ComponentService) BulkUpdateStatus(ctx context.Context, pageID string, statuses map[string]string, opts *BulkUpdateOptions) (_ map[string]*BulkStatusResult, err error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	if opts == nil {
+		opts = &BulkUpdateOptions{}
+	}
+
+	ids := make([]string, 0, len(statuses))
+	for id := range statuses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	ctx, end := s.client.startOperation(ctx, "ComponentService.BulkUpdateStatus", pageID, ids...)
+	defer func() { end(err) }()
+
+	if opts.Rollback {
+		ctx = withoutQueue(ctx)
+	}
+
+	components, err := s.ListComponents(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components: %s", err)
+	}
+
+	previous := make(map[string]string, len(components))
+	for _, c := range components {
+		previous[c.ID] = c.Status
+	}
+
+	results := make(map[string]*BulkStatusResult, len(ids))
+	for _, id := range ids {
+		results[id] = &BulkStatusResult{ComponentID: id, PreviousStatus: previous[id], Status: statuses[id]}
+	}
+
+	unknown := make(ComponentErrors)
+	for _, id := range ids {
+		if _, ok := previous[id]; !ok {
+			results[id].Err = fmt.Errorf("component not found on page %s", pageID)
+			unknown[id] = results[id].Err
+		}
+	}
+	if len(unknown) > 0 {
+		return results, unknown
+	}
+
+	s.client.parallel(len(ids), func(i int) {
+		r := results[ids[i]]
+		r.Component, r.Err = s.UpdateComponent(ctx, pageID, r.ComponentID, UpdateComponentParams{Status: Value(r.Status)})
+		if r.Err != nil {
+			r.Component = nil
+		}
+	})
+
+	failed := make(ComponentErrors)
+	updated := make([]*BulkStatusResult, 0, len(ids))
+	for _, id := range ids {
+		if r := results[id]; r.Err != nil {
+			failed[id] = r.Err
+		} else if r.PreviousStatus != r.Status {
+			updated = append(updated, r)
+		}
+	}
+
+	if len(failed) == 0 {
+		return results, nil
+	}
+
+	if opts.Rollback {
+		timeout := opts.RollbackTimeout
+		if timeout <= 0 {
+			timeout = defaultRollbackTimeout
+		}
+		rollbackCtx, cancel := context.WithTimeout(detach(ctx), timeout)
+		defer cancel()
+
+		s.client.parallel(len(updated), func(i int) {
+			r := updated[i]
+			_, r.RollbackErr = s.UpdateComponent(rollbackCtx, pageID, r.ComponentID, UpdateComponentParams{Status: Value(r.PreviousStatus)})
+			r.RolledBack = r.RollbackErr == nil
+		})
+
+		for _, r := range updated {
+			if r.RollbackErr != nil {
+				failed[r.ComponentID] = fmt.Errorf("failed to roll back to %s: %s", r.PreviousStatus, r.RollbackErr)
+			}
+		}
+	}
+
+	return results, failed
+}
+
+// detachedContext keeps the values of its parent but not its cancellation
+// or deadline
+type detachedContext struct {
+	parent context.Context
+}
+
+// detach returns a context with the values of ctx that is never canceled
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
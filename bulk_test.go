@@ -0,0 +1,148 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestComponentService_BulkUpdateStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"api","status":"operational"},{"id":"web","status":"operational"}]`)
+	})
+	for _, id := range []string{"api", "web"} {
+		id := id
+		mux.HandleFunc("/v1/pages/1/components/"+id, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "PATCH")
+			v := &statuspage.UpdateComponentRequestBody{}
+			json.NewDecoder(r.Body).Decode(v)
+			status, _ := v.Component.Status.Get()
+			fmt.Fprintf(w, `{"id":%q,"status":%q}`, id, status)
+		})
+	}
+
+	statuses := map[string]string{"api": statuspage.StatusMajorOutage, "web": statuspage.StatusDegraded}
+	results, err := client.Component.BulkUpdateStatus(context.Background(), "1", statuses, nil)
+	if err != nil {
+		t.Fatalf("ComponentService.BulkUpdateStatus returned error: %v", err)
+	}
+
+	for id, status := range statuses {
+		r := results[id]
+		if r == nil || r.Component == nil || r.Component.Status != status || r.PreviousStatus != statuspage.StatusOperational {
+			t.Errorf("ComponentService.BulkUpdateStatus result for %s = %+v", id, r)
+		}
+	}
+}
+
+func TestComponentService_BulkUpdateStatus_rollback(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"api","status":"operational"},{"id":"web","status":"degraded_performance"}]`)
+	})
+
+	var mu sync.Mutex
+	var apiStatuses []string
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		v := &statuspage.UpdateComponentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		status, _ := v.Component.Status.Get()
+		mu.Lock()
+		apiStatuses = append(apiStatuses, status)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"id":"api","status":%q}`, status)
+	})
+	mux.HandleFunc("/v1/pages/1/components/web", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"boom"}`, http.StatusInternalServerError)
+	})
+
+	statuses := map[string]string{"api": statuspage.StatusMajorOutage, "web": statuspage.StatusMajorOutage}
+	results, err := client.Component.BulkUpdateStatus(context.Background(), "1", statuses, &statuspage.BulkUpdateOptions{Rollback: true})
+
+	cerr, ok := err.(statuspage.ComponentErrors)
+	if !ok || len(cerr) != 1 || cerr["web"] == nil {
+		t.Fatalf("ComponentService.BulkUpdateStatus returned error %v, want ComponentErrors for web", err)
+	}
+
+	if r := results["api"]; !r.RolledBack || r.RollbackErr != nil {
+		t.Errorf("ComponentService.BulkUpdateStatus result for api = %+v, want rolled back", r)
+	}
+	if r := results["web"]; r.Component != nil || r.PreviousStatus != statuspage.StatusDegraded {
+		t.Errorf("ComponentService.BulkUpdateStatus result for web = %+v", r)
+	}
+
+	want := []string{statuspage.StatusMajorOutage, statuspage.StatusOperational}
+	if len(apiStatuses) != 2 || apiStatuses[0] != want[0] || apiStatuses[1] != want[1] {
+		t.Errorf("api statuses sent = %v, want %v", apiStatuses, want)
+	}
+}
+
+func TestComponentService_BulkUpdateStatus_unknown(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"api","status":"operational"}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no component should be updated when an id is unknown")
+	})
+
+	statuses := map[string]string{"api": statuspage.StatusMajorOutage, "apu": statuspage.StatusMajorOutage}
+	results, err := client.Component.BulkUpdateStatus(context.Background(), "1", statuses, nil)
+
+	cerr, ok := err.(statuspage.ComponentErrors)
+	if !ok || len(cerr) != 1 || cerr["apu"] == nil {
+		t.Fatalf("ComponentService.BulkUpdateStatus returned error %v, want ComponentErrors for apu", err)
+	}
+	if r := results["api"]; r.Component != nil || r.Err != nil {
+		t.Errorf("ComponentService.BulkUpdateStatus result for api = %+v, want untouched", r)
+	}
+}
+
+func TestComponentService_BulkUpdateStatus_rollbackAfterCancel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Concurrency = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"api","status":"operational"},{"id":"web","status":"operational"}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		v := &statuspage.UpdateComponentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		status, _ := v.Component.Status.Get()
+		fmt.Fprintf(w, `{"id":"api","status":%q}`, status)
+	})
+	mux.HandleFunc("/v1/pages/1/components/web", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	statuses := map[string]string{"api": statuspage.StatusMajorOutage, "web": statuspage.StatusMajorOutage}
+	results, err := client.Component.BulkUpdateStatus(ctx, "1", statuses, &statuspage.BulkUpdateOptions{Rollback: true})
+	if err == nil {
+		t.Fatal("ComponentService.BulkUpdateStatus expected error")
+	}
+
+	if r := results["api"]; !r.RolledBack || r.RollbackErr != nil {
+		t.Errorf("ComponentService.BulkUpdateStatus result for api = %+v, want rolled back despite cancellation", r)
+	}
+}
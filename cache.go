@@ -0,0 +1,248 @@
+package statuspage
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache is an http.RoundTripper that serves GET requests from a
+// read-through cache, keyed by URL and credentials. Entries live for TTL and
+// are then revalidated with If-None-Match when the API returned an ETag.
+// Any non-GET request for a page drops that page's entries, so writes made
+// through the same client are never followed by stale reads. Concurrent
+// requests for the same resource share a single round trip.
+type ResponseCache struct {
+	// Transport is used to make requests. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+	TTL       time.Duration
+
+	mu          sync.Mutex
+	entries     map[string]*cacheEntry
+	calls       map[string]*cacheCall
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	pageID  string
+	status  int
+	header  http.Header
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+type cacheCall struct {
+	done  chan struct{}
+	entry *cacheEntry
+	err   error
+
+	// canceled is set when the call failed because its own request's
+	// context ended, which says nothing about the waiters' requests.
+	canceled bool
+
+	// waiters counts the requests waiting on the call. It is guarded by
+	// ResponseCache.mu.
+	waiters int
+}
+
+// NewResponseCache returns a cache that wraps transport and keeps responses for ttl
+func NewResponseCache(transport http.RoundTripper, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		Transport:   transport,
+		TTL:         ttl,
+		entries:     make(map[string]*cacheEntry),
+		calls:       make(map[string]*cacheCall),
+		generations: make(map[string]uint64),
+	}
+}
+
+// NewCachedClient returns a new Statuspage API client whose GET requests are
+// cached for ttl. The cache is available as Client.Cache. If a nil
+// httpClient is provided, http.DefaultClient will be used.
+func NewCachedClient(token string, httpClient *http.Client, ttl time.Duration) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	cache := NewResponseCache(httpClient.Transport, ttl)
+	cached := *httpClient
+	cached.Transport = cache
+
+	c := NewClient(token, &cached)
+	c.Cache = cache
+
+	return c
+}
+
+// Invalidate drops every cached response for a page
+func (rc *ResponseCache) Invalidate(pageID string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.initLocked()
+	rc.invalidateLocked(pageID)
+}
+
+// Purge drops every cached response
+func (rc *ResponseCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, e := range rc.entries {
+		rc.generations[e.pageID]++
+		delete(rc.entries, key)
+	}
+}
+
+func (rc *ResponseCache) initLocked() {
+	if rc.entries == nil {
+		rc.entries = make(map[string]*cacheEntry)
+		rc.calls = make(map[string]*cacheCall)
+		rc.generations = make(map[string]uint64)
+	}
+}
+
+func (rc *ResponseCache) invalidateLocked(pageID string) {
+	rc.generations[pageID]++
+	for key, e := range rc.entries {
+		if e.pageID == pageID {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (rc *ResponseCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	pageID := pageIDFromPath(req.URL.Path)
+
+	if req.Method != http.MethodGet {
+		resp, err := rc.transport().RoundTrip(req)
+		rc.Invalidate(pageID)
+		return resp, err
+	}
+
+	key := req.URL.String() + "\n" + req.Header.Get("Authorization")
+
+	for {
+		rc.mu.Lock()
+		rc.initLocked()
+		entry, ok := rc.entries[key]
+		if ok && time.Now().Before(entry.expires) {
+			rc.mu.Unlock()
+			return entry.response(req), nil
+		}
+		if call, ok := rc.calls[key]; ok {
+			call.waiters++
+			rc.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-req.Context().Done():
+				rc.mu.Lock()
+				call.waiters--
+				rc.mu.Unlock()
+				return nil, req.Context().Err()
+			}
+			if call.canceled {
+				// The leader gave up on its own context; try again rather
+				// than report its cancellation as ours.
+				continue
+			}
+			if call.err != nil {
+				return nil, call.err
+			}
+			return call.entry.response(req), nil
+		}
+
+		call := &cacheCall{done: make(chan struct{})}
+		rc.calls[key] = call
+		generation := rc.generations[pageID]
+		rc.mu.Unlock()
+
+		call.entry, call.err = rc.fetch(req, pageID, entry)
+		call.canceled = call.err != nil && req.Context().Err() != nil
+
+		rc.mu.Lock()
+		delete(rc.calls, key)
+		if call.err == nil && call.entry.status == http.StatusOK && rc.generations[pageID] == generation {
+			rc.entries[key] = call.entry
+		}
+		rc.mu.Unlock()
+		close(call.done)
+
+		if call.err != nil {
+			return nil, call.err
+		}
+		return call.entry.response(req), nil
+	}
+}
+
+// fetch performs the request, revalidating stale when it carries an ETag
+func (rc *ResponseCache) fetch(req *http.Request, pageID string, stale *cacheEntry) (*cacheEntry, error) {
+	if stale != nil && stale.etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", stale.etag)
+	}
+
+	resp, err := rc.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
+		refreshed := *stale
+		refreshed.expires = time.Now().Add(rc.TTL)
+		return &refreshed, nil
+	}
+
+	return &cacheEntry{
+		pageID:  pageID,
+		status:  resp.StatusCode,
+		header:  resp.Header,
+		body:    body,
+		etag:    resp.Header.Get("ETag"),
+		expires: time.Now().Add(rc.TTL),
+	}, nil
+}
+
+func (rc *ResponseCache) transport() http.RoundTripper {
+	if rc.Transport != nil {
+		return rc.Transport
+	}
+	return http.DefaultTransport
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.status) + " " + http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// pageIDFromPath returns the id following the "pages" segment of an API path
+func pageIDFromPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "pages" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
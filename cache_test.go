@@ -0,0 +1,202 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func setupCached(ttl time.Duration) (client *statuspage.Client, mux *http.ServeMux, teardown func()) {
+	_, mux, serverURL, teardown := setup()
+
+	client = statuspage.NewCachedClient("test-token", nil, ttl)
+	url, _ := url.Parse(serverURL + baseURLPath + "/")
+	client.BaseURL = url
+
+	return client, mux, teardown
+}
+
+func TestCachedClient_ListComponents(t *testing.T) {
+	client, mux, teardown := setupCached(time.Minute)
+	defer teardown()
+
+	var calls int32
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `[{"id":"1"}]`)
+	})
+
+	for i := 0; i < 3; i++ {
+		components, err := client.Component.ListComponents(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("ComponentService.ListComponents returned error: %v", err)
+		}
+		if len(components) != 1 {
+			t.Errorf("ComponentService.ListComponents returned %+v, want 1 component", components)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestCachedClient_invalidateOnWrite(t *testing.T) {
+	client, mux, teardown := setupCached(time.Minute)
+	defer teardown()
+
+	var lists int32
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&lists, 1)
+		fmt.Fprint(w, `[{"id":"2"}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":"2"}`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.Component.ListComponents(ctx, "1"); err != nil {
+		t.Fatalf("ComponentService.ListComponents returned error: %v", err)
+	}
+	if _, err := client.Component.UpdateComponent(ctx, "1", "2", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusMajorOutage)}); err != nil {
+		t.Fatalf("ComponentService.UpdateComponent returned error: %v", err)
+	}
+	if _, err := client.Component.ListComponents(ctx, "1"); err != nil {
+		t.Fatalf("ComponentService.ListComponents returned error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&lists); got != 2 {
+		t.Errorf("server received %d list requests, want 2", got)
+	}
+}
+
+func TestCachedClient_etag(t *testing.T) {
+	client, mux, teardown := setupCached(0)
+	defer teardown()
+
+	var full, notModified int32
+	mux.HandleFunc("/v1/pages/1/component-groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[{"id":"g"}]`)
+	})
+
+	for i := 0; i < 2; i++ {
+		groups, err := client.Group.GetGroups(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("GroupService.GetGroups returned error: %v", err)
+		}
+		if len(groups) != 1 || groups[0].ID != "g" {
+			t.Errorf("GroupService.GetGroups returned %+v, want group g", groups)
+		}
+	}
+
+	if full != 1 || notModified != 1 {
+		t.Errorf("server sent %d full and %d not modified responses, want 1 and 1", full, notModified)
+	}
+}
+
+func TestCachedClient_singleflight(t *testing.T) {
+	client, mux, teardown := setupCached(time.Minute)
+	defer teardown()
+
+	var calls int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		fmt.Fprint(w, `[{"id":"1"}]`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Component.ListComponents(context.Background(), "1"); err != nil {
+				t.Errorf("ComponentService.ListComponents returned error: %v", err)
+			}
+		}()
+	}
+
+	// Release the leader once the server has seen it and the other four
+	// callers are waiting on its round trip.
+	for atomic.LoadInt32(&calls) == 0 || client.Cache.Waiting() < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestCachedClient_singleflightContext(t *testing.T) {
+	client, mux, teardown := setupCached(time.Minute)
+	defer teardown()
+
+	var calls int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		fmt.Fprint(w, `[{"id":"1"}]`)
+	})
+	defer close(release)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderDone := make(chan error)
+	go func() {
+		_, err := client.Component.ListComponents(leaderCtx, "1")
+		leaderDone <- err
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A waiter gives up at its own deadline, however long the leader takes.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Component.ListComponents(ctx, "1"); err == nil {
+		t.Error("ComponentService.ListComponents expected deadline error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waiter returned after %v, want close to its deadline", elapsed)
+	}
+
+	// A waiter does not inherit the leader's cancellation.
+	waiterDone := make(chan error)
+	go func() {
+		_, err := client.Component.ListComponents(context.Background(), "1")
+		waiterDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancelLeader()
+
+	if err := <-leaderDone; err == nil {
+		t.Error("canceled leader expected error")
+	}
+	if err := <-waiterDone; err != nil {
+		t.Errorf("waiter returned error %v after leader was canceled", err)
+	}
+}
@@ -40,10 +40,11 @@ func (s *ComponentService) GetComponent(ctx context.Context, pageID string, comp
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "ComponentService.GetComponent", pageID, componentID)
 	var component Component
 	_, err = s.client.do(ctx, req, &component)
 
-	return &component, err
+	return &component, end(err)
 }
 
 // ListComponents returns a list of all components for a given page id
@@ -54,10 +55,11 @@ func (s *ComponentService) ListComponents(ctx context.Context, pageID string) ([
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "ComponentService.ListComponents", pageID)
 	var components []Component
 	_, err = s.client.do(ctx, req, &components)
 
-	return components, err
+	return components, end(err)
 }
 
 // DeleteComponent deletes a component for a given page and component id
@@ -68,19 +70,24 @@ func (s *ComponentService) DeleteComponent(ctx context.Context, pageID string, c
 		return err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "ComponentService.DeleteComponent", pageID, componentID)
 	_, err = s.client.do(ctx, req, nil)
-	return err
+	if err == nil {
+		s.client.names.forget(pageID)
+	}
+	return end(err)
 }
 
-// UpdateComponentParams are the parameters that can be changed using the update component API endpoint
+// UpdateComponentParams are the parameters that can be changed using the update component API endpoint.
+// Fields that are not set are left unchanged.
 type UpdateComponentParams struct {
-	Description        string    `json:"description,omitempty"`
-	Status             string    `json:"status,omitempty"`
-	Name               string    `json:"name,omitempty"`
-	OnlyShowIfDegraded bool      `json:"only_show_if_degraded,omitempty"`
-	GroupID            string    `json:"group_id,omitempty"`
-	Showcase           bool      `json:"showcase,omitempty"`
-	StartDate          Timestamp `json:"start_date,omitempty"`
+	Description        Nullable[string]    `json:"description,omitempty"`
+	Status             Nullable[string]    `json:"status,omitempty"`
+	Name               Nullable[string]    `json:"name,omitempty"`
+	OnlyShowIfDegraded Nullable[bool]      `json:"only_show_if_degraded,omitempty"`
+	GroupID            Nullable[string]    `json:"group_id,omitempty"`
+	Showcase           Nullable[bool]      `json:"showcase,omitempty"`
+	StartDate          Nullable[Timestamp] `json:"start_date,omitempty"`
 }
 
 // UpdateComponentRequestBody is the update component request body representation
@@ -101,8 +108,12 @@ func (s *ComponentService) UpdateComponent(ctx context.Context, pageID string, c
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "ComponentService.UpdateComponent", pageID, componentID)
 	var updatedComponent Component
 	_, err = s.client.do(ctx, req, &updatedComponent)
+	if err == nil && (component.Name.IsSet() || component.GroupID.IsSet()) {
+		s.client.names.forget(pageID)
+	}
 
-	return &updatedComponent, err
+	return &updatedComponent, end(err)
 }
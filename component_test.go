@@ -132,7 +132,7 @@ func TestComponentService_UpdateComponent(t *testing.T) {
 	})
 
 	componentParams := statuspage.UpdateComponentParams{
-		Status: "major_outage",
+		Status: statuspage.Value("major_outage"),
 	}
 	updatedComponent, err := client.Component.UpdateComponent(context.Background(), "1", "2", componentParams)
 	if err != nil {
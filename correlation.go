@@ -0,0 +1,237 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+)
+
+// CorrelationPolicy decides what SetComponentStatus does with the
+// unresolved incidents of a component whose status it changes
+type CorrelationPolicy int
+
+const (
+	// PolicyStatusOnly only changes the component status
+	PolicyStatusOnly CorrelationPolicy = iota
+	// PolicyAttach also records the new status on every unresolved incident
+	// that already covers the component. When none does and the component is
+	// no longer operational, it is attached to an unresolved incident: the
+	// one named in the options, or else the most recently created one that
+	// is not a scheduled maintenance.
+	PolicyAttach
+	// PolicyAttachOrOpen behaves like PolicyAttach, and opens a new incident
+	// from the template when there is no incident to attach the component to
+	PolicyAttachOrOpen
+)
+
+// Actions reported by ComponentStatusSummary
+const (
+	CorrelationStatusOnly = "status_only"
+	CorrelationAttached   = "attached"
+	CorrelationOpened     = "opened"
+)
+
+// IncidentTemplate returns the incident to open for a component that is
+// changing to status. The component is added to the incident with that
+// status, so the template does not need to.
+type IncidentTemplate func(component *Component, status string) *IncidentBuilder
+
+// SetComponentStatusOptions specifies the optional parameters to the
+// SetComponentStatus method
+type SetComponentStatusOptions struct {
+	Policy CorrelationPolicy
+
+	// IncidentID names the unresolved incident a component is attached to
+	// when no incident covers it yet. The default is the most recently
+	// created unresolved incident that is not a scheduled maintenance.
+	IncidentID string
+
+	// Template builds the incident opened under PolicyAttachOrOpen. The
+	// default is an investigating incident named after the component.
+	Template IncidentTemplate
+}
+
+// ComponentStatusSummary describes what SetComponentStatus did
+type ComponentStatusSummary struct {
+	Component      *Component
+	PreviousStatus string
+
+	// Action is one of CorrelationStatusOnly, CorrelationAttached or
+	// CorrelationOpened.
+	Action string
+
+	// Incidents are the unresolved incidents covering the component, as
+	// updated when it was attached to them, or the incident opened for it.
+	Incidents []Incident
+}
+
+func defaultIncidentTemplate(component *Component, status string) *IncidentBuilder {
+	return NewIncidentBuilder(component.Name + " is experiencing issues").
+		Status(StatusInvestigating).
+		Body(fmt.Sprintf("%s is reporting %s. We are investigating.", component.Name, status)).
+		DeliverNotifications(true)
+}
+
+// SetComponentStatus changes the status of a component and correlates the
+// change with the unresolved incidents of the page, as set by the policy in
+// opts. A nil opts only changes the status, though the summary still lists
+// the incidents covering the component. Incidents the API would reject,
+// such as a maintenance status on a realtime incident, are caught before
+// the component is changed.
+func (c *Client) SetComponentStatus(ctx context.Context, pageID, componentID, status string, opts *SetComponentStatusOptions) (_ *ComponentStatusSummary, err error) {
+	if pageID == "" {
+		pageID = c.defaultPage
+	}
+
+	ctx, end := c.startOperation(ctx, "Client.SetComponentStatus", pageID, componentID)
+	defer func() { end(err) }()
+
+	if opts == nil {
+		opts = &SetComponentStatusOptions{}
+	}
+
+	previous, err := c.Component.GetComponent(ctx, pageID, componentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component: %s", err)
+	}
+
+	unresolved, err := c.Incident.listAllUnresolvedIncidents(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unresolved incidents: %s", err)
+	}
+
+	summary := &ComponentStatusSummary{
+		PreviousStatus: previous.Status,
+		Action:         CorrelationStatusOnly,
+	}
+	for _, incident := range unresolved {
+		if incidentCovers(incident, componentID) {
+			summary.Incidents = append(summary.Incidents, incident)
+		}
+	}
+
+	// Decide what to do with the incidents, and check that the API will
+	// accept it, before anything is changed.
+	var target *Incident
+	var open *IncidentBuilder
+	switch {
+	case opts.Policy == PolicyStatusOnly:
+
+	case len(summary.Incidents) > 0:
+		for _, incident := range summary.Incidents {
+			if err := checkAttachable(incident, componentID, status); err != nil {
+				return nil, err
+			}
+		}
+
+	case status != StatusOperational:
+		target, err = attachTarget(unresolved, opts.IncidentID)
+		if err != nil {
+			return nil, err
+		}
+		if target != nil {
+			if err := checkAttachable(*target, componentID, status); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		if opts.Policy == PolicyAttachOrOpen {
+			template := opts.Template
+			if template == nil {
+				template = defaultIncidentTemplate
+			}
+
+			pending := *previous
+			pending.Status = status
+			open = template(&pending, status).Component(componentID, status)
+			if _, err := open.Build(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	summary.Component, err = c.Component.UpdateComponent(ctx, pageID, componentID, UpdateComponentParams{Status: Value(status)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update component: %s", err)
+	}
+
+	switch {
+	case opts.Policy == PolicyStatusOnly:
+
+	case len(summary.Incidents) > 0:
+		for i, incident := range summary.Incidents {
+			patch := IncidentPatch{Components: map[string]string{componentID: status}}
+			updated, err := c.Incident.PatchIncident(ctx, pageID, incident.ID, patch)
+			if err != nil {
+				return summary, fmt.Errorf("failed to attach component to incident %s: %s", incident.ID, err)
+			}
+			summary.Incidents[i] = *updated
+		}
+		summary.Action = CorrelationAttached
+
+	case target != nil:
+		patch := IncidentPatch{
+			Components:   map[string]string{componentID: status},
+			ComponentIDs: append(append([]string(nil), incidentComponentIDs(*target)...), componentID),
+		}
+		updated, err := c.Incident.PatchIncident(ctx, pageID, target.ID, patch)
+		if err != nil {
+			return summary, fmt.Errorf("failed to attach component to incident %s: %s", target.ID, err)
+		}
+		summary.Incidents = []Incident{*updated}
+		summary.Action = CorrelationAttached
+
+	case open != nil:
+		incident, err := c.Incident.CreateIncidentWith(ctx, pageID, open)
+		if err != nil {
+			return summary, fmt.Errorf("failed to open incident: %s", err)
+		}
+		summary.Incidents = []Incident{*incident}
+		summary.Action = CorrelationOpened
+	}
+
+	return summary, nil
+}
+
+// attachTarget returns the unresolved incident with the given id, or when id
+// is empty the most recently created one that is not a scheduled
+// maintenance. It returns nil when there is no such incident.
+func attachTarget(unresolved []Incident, id string) (*Incident, error) {
+	var target *Incident
+	for i := range unresolved {
+		incident := &unresolved[i]
+		switch {
+		case id != "":
+			if incident.ID == id {
+				return incident, nil
+			}
+		case incident.ScheduledFor == nil && (target == nil || incident.CreatedAt.After(target.CreatedAt.Time)):
+			target = incident
+		}
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf("incident %s is not unresolved", id)
+	}
+	return target, nil
+}
+
+// checkAttachable reports whether the API accepts status for a component of
+// incident
+func checkAttachable(incident Incident, componentID, status string) error {
+	if status == StatusMaintenance && incident.ScheduledFor == nil {
+		return &IncidentValidationError{Problems: []string{
+			fmt.Sprintf("component %s cannot be under maintenance in realtime incident %s", componentID, incident.ID),
+		}}
+	}
+	return nil
+}
+
+func incidentCovers(incident Incident, componentID string) bool {
+	for _, id := range incidentComponentIDs(incident) {
+		if id == componentID {
+			return true
+		}
+	}
+	return false
+}
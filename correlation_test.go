@@ -0,0 +1,190 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func handleComponent(t *testing.T, mux *http.ServeMux, want string) {
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"api","name":"API","status":"operational"}`)
+		case "PATCH":
+			v := &statuspage.UpdateComponentRequestBody{}
+			json.NewDecoder(r.Body).Decode(v)
+			status, _ := v.Component.Status.Get()
+			if status != want {
+				t.Errorf("Request status = %q, want %q", status, want)
+			}
+			fmt.Fprintf(w, `{"id":"api","name":"API","status":%q}`, status)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+func TestClient_SetComponentStatus_attach(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleComponent(t, mux, statuspage.StatusMajorOutage)
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"i1","components":[{"id":"api"}]},{"id":"i2","components":[{"id":"web"}]}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		v := &statuspage.PatchIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		want := map[string]string{"api": statuspage.StatusMajorOutage}
+		if !reflect.DeepEqual(v.Incident.Components, want) {
+			t.Errorf("Request components = %v, want %v", v.Incident.Components, want)
+		}
+		fmt.Fprint(w, `{"id":"i1","components":[{"id":"api","status":"major_outage"}]}`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/i2", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("incident not covering the component should not be updated")
+	})
+
+	opts := &statuspage.SetComponentStatusOptions{Policy: statuspage.PolicyAttachOrOpen}
+	summary, err := client.SetComponentStatus(context.Background(), "1", "api", statuspage.StatusMajorOutage, opts)
+	if err != nil {
+		t.Fatalf("Client.SetComponentStatus returned error: %v", err)
+	}
+
+	if summary.Action != statuspage.CorrelationAttached {
+		t.Errorf("Client.SetComponentStatus action = %q, want %q", summary.Action, statuspage.CorrelationAttached)
+	}
+	if summary.PreviousStatus != statuspage.StatusOperational {
+		t.Errorf("Client.SetComponentStatus previous status = %q, want %q", summary.PreviousStatus, statuspage.StatusOperational)
+	}
+	if len(summary.Incidents) != 1 || summary.Incidents[0].Components[0].Status != statuspage.StatusMajorOutage {
+		t.Errorf("Client.SetComponentStatus incidents = %+v, want updated i1", summary.Incidents)
+	}
+}
+
+func TestClient_SetComponentStatus_open(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleComponent(t, mux, statuspage.StatusDegraded)
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+
+		want := statuspage.IncidentUpdate{
+			Name:         statuspage.Value("API is slow"),
+			Status:       statuspage.Value(statuspage.StatusIdentified),
+			Components:   map[string]string{"api": statuspage.StatusDegraded},
+			ComponentIDs: []string{"api"},
+		}
+		if !reflect.DeepEqual(v.Incident, want) {
+			t.Errorf("Request body = %+v, want %+v", v.Incident, want)
+		}
+		fmt.Fprint(w, `{"id":"i3"}`)
+	})
+
+	opts := &statuspage.SetComponentStatusOptions{
+		Policy: statuspage.PolicyAttachOrOpen,
+		Template: func(component *statuspage.Component, status string) *statuspage.IncidentBuilder {
+			return statuspage.NewIncidentBuilder(component.Name + " is slow").Status(statuspage.StatusIdentified)
+		},
+	}
+	summary, err := client.SetComponentStatus(context.Background(), "1", "api", statuspage.StatusDegraded, opts)
+	if err != nil {
+		t.Fatalf("Client.SetComponentStatus returned error: %v", err)
+	}
+
+	if summary.Action != statuspage.CorrelationOpened || len(summary.Incidents) != 1 || summary.Incidents[0].ID != "i3" {
+		t.Errorf("Client.SetComponentStatus returned %+v, want opened i3", summary)
+	}
+}
+
+func TestClient_SetComponentStatus_statusOnly(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleComponent(t, mux, statuspage.StatusDegraded)
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"i1","components":[{"id":"api"}]}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("incident should not be updated without a policy")
+	})
+
+	summary, err := client.SetComponentStatus(context.Background(), "1", "api", statuspage.StatusDegraded, nil)
+	if err != nil {
+		t.Fatalf("Client.SetComponentStatus returned error: %v", err)
+	}
+
+	if summary.Action != statuspage.CorrelationStatusOnly || len(summary.Incidents) != 1 {
+		t.Errorf("Client.SetComponentStatus returned %+v, want status only with i1 listed", summary)
+	}
+}
+
+func TestClient_SetComponentStatus_attachToExisting(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleComponent(t, mux, statuspage.StatusMajorOutage)
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id":"old","created_at":"2006-01-02T15:04:05Z","components":[{"id":"db"}]},
+			{"id":"new","created_at":"2006-01-03T15:04:05Z","components":[{"id":"web"}]},
+			{"id":"maint","created_at":"2006-01-04T15:04:05Z","scheduled_for":"2006-01-05T15:04:05Z"}
+		]`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/new", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		want := `{"incident":{"components":{"api":"major_outage"},"component_ids":["web","api"]}}` + "\n"
+		if string(body) != want {
+			t.Errorf("Request body = %s, want %s", body, want)
+		}
+		fmt.Fprint(w, `{"id":"new"}`)
+	})
+
+	opts := &statuspage.SetComponentStatusOptions{Policy: statuspage.PolicyAttach}
+	summary, err := client.SetComponentStatus(context.Background(), "1", "api", statuspage.StatusMajorOutage, opts)
+	if err != nil {
+		t.Fatalf("Client.SetComponentStatus returned error: %v", err)
+	}
+
+	if summary.Action != statuspage.CorrelationAttached || len(summary.Incidents) != 1 || summary.Incidents[0].ID != "new" {
+		t.Errorf("Client.SetComponentStatus returned %+v, want attached to new", summary)
+	}
+}
+
+func TestClient_SetComponentStatus_invalidBeforeWrite(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"api","name":"API","status":"operational"}`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid incident should not be opened")
+	})
+
+	opts := &statuspage.SetComponentStatusOptions{Policy: statuspage.PolicyAttachOrOpen}
+	_, err := client.SetComponentStatus(context.Background(), "1", "api", statuspage.StatusMaintenance, opts)
+	if _, ok := err.(*statuspage.IncidentValidationError); !ok {
+		t.Errorf("Client.SetComponentStatus returned %v, want *IncidentValidationError", err)
+	}
+}
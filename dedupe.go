@@ -0,0 +1,168 @@
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DedupeMetadataKey is where CreateIncidentOnce stores the dedupe key, under
+// MetadataNamespace in the incident metadata
+const DedupeMetadataKey = "dedupe_key"
+
+// CreateIncidentOnce creates the incident in b unless an unresolved incident
+// with the same dedupe key already exists, in which case that incident is
+// returned and reused is true. The key is stored in the new incident's
+// metadata, so retries of the same alert, even from other processes, find
+// it.
+//
+// Searching and creating are separate requests, so two callers racing with
+// the same key may both create an incident. Set Client.DedupeCache to rule
+// this out between goroutines of the same process. An incident remembered by
+// the cache is fetched again to check that it is still unresolved. Incidents
+// queued in Client.Outbox are deduped when they are replayed.
+func (s *IncidentService) CreateIncidentOnce(ctx context.Context, pageID, dedupeKey string, b *IncidentBuilder) (incident *Incident, reused bool, err error) {
+	if dedupeKey == "" {
+		return nil, false, fmt.Errorf("statuspage: empty dedupe key")
+	}
+
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	ctx, end := s.client.startOperation(ctx, "IncidentService.CreateIncidentOnce", pageID)
+	defer func() { end(err) }()
+
+	if cache := s.client.DedupeCache; cache != nil {
+		entry := cache.acquire(pageID + "/" + dedupeKey)
+		defer cache.release(entry)
+
+		if cached := cache.lookup(entry); cached != nil {
+			current, err := s.GetIncident(ctx, pageID, cached.ID)
+			if err != nil && !isNotFound(err) {
+				return nil, false, err
+			}
+			if err == nil && !isResolved(current.Status) {
+				return current, true, nil
+			}
+		}
+		defer func() {
+			if err == nil {
+				cache.store(entry, incident)
+			}
+		}()
+	}
+
+	found, err := s.FindUnresolvedIncidentsByMetadata(ctx, pageID, MetadataNamespace, DedupeMetadataKey, dedupeKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(found) > 0 {
+		return &found[0], true, nil
+	}
+
+	tagged := *b
+	tagged.incident.Metadata = b.incident.Metadata.Copy()
+	tagged.Metadata(MetadataNamespace, DedupeMetadataKey, dedupeKey)
+
+	incident, err = s.CreateIncidentWith(ctx, pageID, &tagged)
+	if err != nil {
+		return nil, false, err
+	}
+	return incident, false, nil
+}
+
+// isResolved reports whether an incident with status is over
+func isResolved(status string) bool {
+	return status == StatusResolved || status == StatusCompleted
+}
+
+func isNotFound(err error) bool {
+	var rerr *ErrorResponse
+	return errors.As(err, &rerr) && rerr.Response.StatusCode == http.StatusNotFound
+}
+
+// DedupeCache makes CreateIncidentOnce calls with the same page and dedupe
+// key run one at a time, and remembers the incident they resolved to for
+// TTL so that later calls reuse it without searching.
+type DedupeCache struct {
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*dedupeEntry
+}
+
+type dedupeEntry struct {
+	key string
+
+	// call is held for the whole of a CreateIncidentOnce call.
+	call sync.Mutex
+
+	// The remaining fields are guarded by DedupeCache.mu.
+	users    int
+	incident *Incident
+	expires  time.Time
+}
+
+// NewDedupeCache returns a DedupeCache that remembers incidents for ttl
+func NewDedupeCache(ttl time.Duration) *DedupeCache {
+	return &DedupeCache{TTL: ttl}
+}
+
+// acquire returns the entry for key, once no other call is using it
+func (d *DedupeCache) acquire(key string) *dedupeEntry {
+	d.mu.Lock()
+	if d.entries == nil {
+		d.entries = make(map[string]*dedupeEntry)
+	}
+	entry, ok := d.entries[key]
+	if !ok {
+		entry = &dedupeEntry{key: key}
+		d.entries[key] = entry
+	}
+	entry.users++
+	d.mu.Unlock()
+
+	entry.call.Lock()
+	return entry
+}
+
+// release lets the next call use entry, and drops entries that are no
+// longer in use and hold no live incident
+func (d *DedupeCache) release(entry *dedupeEntry) {
+	entry.call.Unlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry.users--
+	now := time.Now()
+	for key, e := range d.entries {
+		if e.users == 0 && !now.Before(e.expires) {
+			delete(d.entries, key)
+		}
+	}
+}
+
+func (d *DedupeCache) lookup(entry *dedupeEntry) *Incident {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry.incident == nil || !time.Now().Before(entry.expires) {
+		return nil
+	}
+	incident := *entry.incident
+	return &incident
+}
+
+func (d *DedupeCache) store(entry *dedupeEntry, incident *Incident) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored := *incident
+	entry.incident = &stored
+	entry.expires = time.Now().Add(d.TTL)
+}
@@ -0,0 +1,234 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+// handleDedupe serves an incidents endpoint that remembers the incidents
+// created through it. It returns the number of creations so far and a
+// function that resolves an incident.
+func handleDedupe(t *testing.T, mux *http.ServeMux) (func() int, func(id string)) {
+	var mu sync.Mutex
+	var created []string
+	resolved := make(map[string]bool)
+
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		key, _ := v.Incident.Metadata.GetString(statuspage.MetadataNamespace, statuspage.DedupeMetadataKey)
+
+		mu.Lock()
+		created = append(created, key)
+		id := len(created)
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"id":"i%d","metadata":{%q:{%q:%q}}}`, id, statuspage.MetadataNamespace, statuspage.DedupeMetadataKey, key)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		defer mu.Unlock()
+
+		incidents := []json.RawMessage{}
+		for i, key := range created {
+			if resolved[fmt.Sprintf("i%d", i+1)] {
+				continue
+			}
+			incidents = append(incidents, json.RawMessage(fmt.Sprintf(`{"id":"i%d","metadata":{%q:{%q:%q}}}`, i+1, statuspage.MetadataNamespace, statuspage.DedupeMetadataKey, key)))
+		}
+		json.NewEncoder(w).Encode(incidents)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/v1/pages/1/incidents/")
+		status := statuspage.StatusInvestigating
+		if resolved[id] {
+			status = statuspage.StatusResolved
+		}
+		fmt.Fprintf(w, `{"id":%q,"status":%q}`, id, status)
+	})
+
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(created)
+	}
+	resolve := func(id string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved[id] = true
+	}
+	return count, resolve
+}
+
+func TestIncidentService_CreateIncidentOnce(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	count, _ := handleDedupe(t, mux)
+
+	b := statuspage.NewIncidentBuilder("API errors").Component("api", statuspage.StatusMajorOutage)
+	incident, reused, err := client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b)
+	if err != nil {
+		t.Fatalf("IncidentService.CreateIncidentOnce returned error: %v", err)
+	}
+	if reused || incident.ID != "i1" {
+		t.Errorf("IncidentService.CreateIncidentOnce = %v, %v, want new incident i1", incident.ID, reused)
+	}
+
+	incident, reused, err = client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b)
+	if err != nil {
+		t.Fatalf("IncidentService.CreateIncidentOnce returned error: %v", err)
+	}
+	if !reused || incident.ID != "i1" {
+		t.Errorf("IncidentService.CreateIncidentOnce = %v, %v, want reused incident i1", incident.ID, reused)
+	}
+
+	if _, reused, _ := client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-2", b); reused {
+		t.Error("IncidentService.CreateIncidentOnce reused an incident with another key")
+	}
+	if n := count(); n != 2 {
+		t.Errorf("created %d incidents, want 2", n)
+	}
+
+	// The caller's builder is left untouched.
+	update, _ := b.Build()
+	if _, ok := update.Metadata.GetString(statuspage.MetadataNamespace, statuspage.DedupeMetadataKey); ok {
+		t.Error("IncidentService.CreateIncidentOnce modified the builder's metadata")
+	}
+}
+
+func TestIncidentService_CreateIncidentOnce_cache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.DedupeCache = statuspage.NewDedupeCache(time.Minute)
+	count, _ := handleDedupe(t, mux)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reusedCount := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b := statuspage.NewIncidentBuilder("API errors")
+			_, reused, err := client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b)
+			if err != nil {
+				t.Errorf("IncidentService.CreateIncidentOnce returned error: %v", err)
+			}
+			if reused {
+				mu.Lock()
+				reusedCount++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := count(); n != 1 {
+		t.Errorf("created %d incidents, want 1", n)
+	}
+	if reusedCount != 7 {
+		t.Errorf("%d calls reused the incident, want 7", reusedCount)
+	}
+}
+
+func TestIncidentService_CreateIncidentOnce_cacheResolved(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.DedupeCache = statuspage.NewDedupeCache(time.Minute)
+	count, resolve := handleDedupe(t, mux)
+
+	b := statuspage.NewIncidentBuilder("API errors")
+	if _, _, err := client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b); err != nil {
+		t.Fatalf("IncidentService.CreateIncidentOnce returned error: %v", err)
+	}
+	incident, reused, err := client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b)
+	if err != nil || !reused || incident.Status != statuspage.StatusInvestigating {
+		t.Fatalf("IncidentService.CreateIncidentOnce returned %+v, %v, %v, want cached i1 reused", incident, reused, err)
+	}
+
+	resolve("i1")
+	incident, reused, err = client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b)
+	if err != nil {
+		t.Fatalf("IncidentService.CreateIncidentOnce returned error: %v", err)
+	}
+	if count() != 2 || reused || incident.ID != "i2" {
+		t.Errorf("IncidentService.CreateIncidentOnce returned %+v, reused %v, want new i2", incident, reused)
+	}
+}
+
+func TestIncidentService_CreateIncidentOnce_emptyKey(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	b := statuspage.NewIncidentBuilder("API errors")
+	if _, _, err := client.Incident.CreateIncidentOnce(context.Background(), "1", "", b); err == nil {
+		t.Error("IncidentService.CreateIncidentOnce expected error for an empty dedupe key")
+	}
+}
+
+func TestIncidentService_CreateIncidentOnce_outbox(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	outbox, err := statuspage.OpenOutbox(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatalf("OpenOutbox returned error: %v", err)
+	}
+	client.Outbox = outbox
+
+	down := true
+	var created []json.RawMessage
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			json.NewEncoder(w).Encode(created)
+			return
+		}
+		if down {
+			http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		incident, _ := json.Marshal(statuspage.Incident{ID: fmt.Sprintf("i%d", len(created)+1), Metadata: v.Incident.Metadata})
+		created = append(created, incident)
+		w.Write(incident)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(created)
+	})
+
+	for i := 0; i < 3; i++ {
+		b := statuspage.NewIncidentBuilder("API errors")
+		_, _, err := client.Incident.CreateIncidentOnce(context.Background(), "1", "alert-1", b)
+		if _, ok := err.(*statuspage.QueuedError); !ok {
+			t.Fatalf("IncidentService.CreateIncidentOnce returned %v, want *QueuedError", err)
+		}
+	}
+
+	down = false
+	if n, err := outbox.Replay(context.Background(), client); err != nil || n != 3 {
+		t.Fatalf("Outbox.Replay = %d, %v, want 3", n, err)
+	}
+	if len(created) != 1 {
+		t.Errorf("created %d incidents, want 1", len(created))
+	}
+}
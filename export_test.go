@@ -0,0 +1,14 @@
+package statuspage
+
+// Waiting returns the number of requests waiting on another request's round
+// trip
+func (rc *ResponseCache) Waiting() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	n := 0
+	for _, call := range rc.calls {
+		n += call.waiters
+	}
+	return n
+}
@@ -27,10 +27,11 @@ func (s *GroupService) GetGroup(ctx context.Context, pageID string, groupID stri
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "GroupService.GetGroup", pageID)
 	var group Group
 	_, err = s.client.do(ctx, req, &group)
 
-	return &group, err
+	return &group, end(err)
 }
 
 // ListComponents returns a list of all components for a given page id
@@ -41,8 +42,9 @@ func (s *GroupService) GetGroups(ctx context.Context, pageID string) ([]Group, e
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "GroupService.GetGroups", pageID)
 	var groups []Group
 	_, err = s.client.do(ctx, req, &groups)
 
-	return groups, err
+	return groups, end(err)
 }
@@ -0,0 +1,162 @@
+package statuspage
+
+import "math"
+
+// Status indicators, as used by Statuspage for the overall state of a page
+const (
+	IndicatorNone     = "none"
+	IndicatorMinor    = "minor"
+	IndicatorMajor    = "major"
+	IndicatorCritical = "critical"
+)
+
+// componentImpact ranks component statuses by how badly they affect a page.
+// Maintenance is planned, so it has no impact of its own.
+var componentImpact = map[string]int{
+	StatusOperational:   0,
+	StatusMaintenance:   0,
+	StatusDegraded:      1,
+	StatusPartialOutage: 2,
+	StatusMajorOutage:   3,
+}
+
+var (
+	statusByImpact    = []string{StatusOperational, StatusDegraded, StatusPartialOutage, StatusMajorOutage}
+	indicatorByImpact = []string{IndicatorNone, IndicatorMinor, IndicatorMajor, IndicatorCritical}
+)
+
+// HealthOptions specifies the optional parameters to ComputePageHealth
+type HealthOptions struct {
+	// Weights scales how much a component counts, by component id. The
+	// impact of its status is multiplied by its weight and rounded, so a
+	// weight of 0.5 turns a major outage into a partial one and a weight of
+	// 0 ignores the component. Components default to 1.
+	Weights map[string]float64
+
+	// VisibleOnly counts only the components shown on the public page:
+	// those with OnlyShowIfDegraded set while they are not operational,
+	// whether or not Showcase is set, and any other with Showcase set.
+	VisibleOnly bool
+}
+
+// PageHealth summarizes how a page is doing
+type PageHealth struct {
+	// Status is the worst weighted component status on the page.
+	Status string
+	// Indicator is IndicatorNone, IndicatorMinor, IndicatorMajor or
+	// IndicatorCritical.
+	Indicator string
+
+	Groups    []GroupHealth
+	Ungrouped GroupHealth
+
+	// Components is the number of components counted.
+	Components int
+}
+
+// GroupHealth summarizes a component group. A group is as bad as its worst
+// weighted component.
+type GroupHealth struct {
+	GroupID    string
+	Name       string
+	Status     string
+	Indicator  string
+	Components int
+}
+
+// ComputePageHealth summarizes the statuses of a page's components, as
+// returned by ListComponents. Group entries are used to name the groups.
+func ComputePageHealth(components []Component, opts *HealthOptions) *PageHealth {
+	return NewPageTopology("", nil, components).Health(opts)
+}
+
+// Health summarizes the statuses of the components of t
+func (t *PageTopology) Health(opts *HealthOptions) *PageHealth {
+	if opts == nil {
+		opts = &HealthOptions{}
+	}
+
+	health := &PageHealth{Groups: make([]GroupHealth, 0, len(t.Groups))}
+	worst, maintenance := 0, false
+
+	rollup := func(id, name string, components []Component) GroupHealth {
+		g := GroupHealth{GroupID: id, Name: name}
+		groupWorst, groupMaintenance := 0, false
+		for _, c := range components {
+			impact, ok := opts.impact(c)
+			if !ok {
+				continue
+			}
+			g.Components++
+			if impact > groupWorst {
+				groupWorst = impact
+			}
+			if c.Status == StatusMaintenance {
+				groupMaintenance = true
+			}
+		}
+
+		if groupWorst > worst {
+			worst = groupWorst
+		}
+		maintenance = maintenance || groupMaintenance
+		health.Components += g.Components
+
+		g.Status = healthStatus(groupWorst, groupMaintenance)
+		g.Indicator = indicatorByImpact[groupWorst]
+		return g
+	}
+
+	for _, g := range t.Groups {
+		health.Groups = append(health.Groups, rollup(g.Group.ID, g.Group.Name, g.Components))
+	}
+	health.Ungrouped = rollup("", "", t.Ungrouped)
+
+	health.Status = healthStatus(worst, maintenance)
+	health.Indicator = indicatorByImpact[worst]
+
+	return health
+}
+
+// healthStatus returns the status for an impact, which is
+// StatusMaintenance rather than StatusOperational while maintenance is
+// under way
+func healthStatus(impact int, maintenance bool) string {
+	if impact == 0 && maintenance {
+		return StatusMaintenance
+	}
+	return statusByImpact[impact]
+}
+
+// impact returns the weighted impact of a component's status, and whether
+// the component counts at all
+func (o *HealthOptions) impact(c Component) (int, bool) {
+	if o.VisibleOnly {
+		visible := c.Showcase
+		if c.OnlyShowIfDegraded {
+			visible = c.Status != StatusOperational
+		}
+		if !visible {
+			return 0, false
+		}
+	}
+
+	impact, ok := componentImpact[c.Status]
+	if !ok {
+		return 0, false
+	}
+
+	weight, ok := o.Weights[c.ID]
+	if !ok {
+		return impact, true
+	}
+	if weight <= 0 {
+		return 0, false
+	}
+
+	weighted := int(math.Round(float64(impact) * weight))
+	if weighted > len(statusByImpact)-1 {
+		weighted = len(statusByImpact) - 1
+	}
+	return weighted, true
+}
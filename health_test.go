@@ -0,0 +1,61 @@
+package statuspage_test
+
+import (
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+var healthComponents = []statuspage.Component{
+	{ID: "g1", Name: "EU", Group: true, Position: 1},
+	{ID: "api", Name: "API", GroupID: "g1", Status: statuspage.StatusPartialOutage, Showcase: true},
+	{ID: "cdn", Name: "CDN", GroupID: "g1", Status: statuspage.StatusMajorOutage, OnlyShowIfDegraded: true},
+	{ID: "g2", Name: "US", Group: true, Position: 2},
+	{ID: "db", Name: "Database", GroupID: "g2", Status: statuspage.StatusMaintenance, Showcase: true},
+	{ID: "web", Name: "Website", Status: statuspage.StatusOperational, Showcase: true},
+}
+
+func TestComputePageHealth(t *testing.T) {
+	health := statuspage.ComputePageHealth(healthComponents, nil)
+
+	want := &statuspage.PageHealth{
+		Status:    statuspage.StatusMajorOutage,
+		Indicator: statuspage.IndicatorCritical,
+		Groups: []statuspage.GroupHealth{
+			{GroupID: "g1", Name: "EU", Status: statuspage.StatusMajorOutage, Indicator: statuspage.IndicatorCritical, Components: 2},
+			{GroupID: "g2", Name: "US", Status: statuspage.StatusMaintenance, Indicator: statuspage.IndicatorNone, Components: 1},
+		},
+		Ungrouped:  statuspage.GroupHealth{Status: statuspage.StatusOperational, Indicator: statuspage.IndicatorNone, Components: 1},
+		Components: 4,
+	}
+	if !reflect.DeepEqual(health, want) {
+		t.Errorf("ComputePageHealth returned %+v, want %+v", health, want)
+	}
+}
+
+func TestComputePageHealth_options(t *testing.T) {
+	opts := &statuspage.HealthOptions{
+		Weights:     map[string]float64{"api": 0.5},
+		VisibleOnly: true,
+	}
+	health := statuspage.ComputePageHealth(healthComponents, opts)
+
+	if health.Status != statuspage.StatusMajorOutage || health.Indicator != statuspage.IndicatorCritical {
+		t.Errorf("ComputePageHealth returned %s/%s, want %s/%s", health.Status, health.Indicator, statuspage.StatusMajorOutage, statuspage.IndicatorCritical)
+	}
+	if health.Components != 4 {
+		t.Errorf("ComputePageHealth counted %d components, want 4", health.Components)
+	}
+
+	// Without the degraded cdn, only the halved api outage is left.
+	health = statuspage.ComputePageHealth(healthComponents[:2], opts)
+	if health.Status != statuspage.StatusDegraded || health.Components != 1 {
+		t.Errorf("ComputePageHealth returned %s with %d components, want %s with 1", health.Status, health.Components, statuspage.StatusDegraded)
+	}
+
+	hidden := []statuspage.Component{{ID: "cdn", Status: statuspage.StatusOperational, Showcase: true, OnlyShowIfDegraded: true}}
+	if health := statuspage.ComputePageHealth(hidden, opts); health.Components != 0 {
+		t.Errorf("ComputePageHealth counted %d components, want hidden component skipped", health.Components)
+	}
+}
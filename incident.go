@@ -2,7 +2,6 @@ package statuspage
 
 import (
 	"context"
-	"fmt"
 )
 
 const (
@@ -11,6 +10,11 @@ const (
 	StatusMonitoring    = "monitoring"
 	StatusResolved      = "resolved"
 
+	StatusScheduled  = "scheduled"
+	StatusInProgress = "in_progress"
+	StatusVerifying  = "verifying"
+	StatusCompleted  = "completed"
+
 	StatusOperational   = "operational"
 	StatusMaintenance   = "under_maintenance"
 	StatusDegraded      = "degraded_performance"
@@ -18,6 +22,14 @@ const (
 	StatusMajorOutage   = "major_outage"
 )
 
+const (
+	ImpactNone        = "none"
+	ImpactMinor       = "minor"
+	ImpactMajor       = "major"
+	ImpactCritical    = "critical"
+	ImpactMaintenance = "maintenance"
+)
+
 type IncidentService service
 
 // UpdateComponentRequestBody is the update component request body representation
@@ -29,74 +41,186 @@ type CreateIncidentRequestBody struct {
 	Incident Incident `json:"incident"`
 }
 
-type IncidentMetadata struct {
-	Value string `json:"value"`
+type Incident struct {
+	ID                   string                 `json:"id,omitempty"`
+	PageID               string                 `json:"page_id,omitempty"`
+	CreatedAt            Timestamp              `json:"created_at,omitempty"`
+	UpdatedAt            Timestamp              `json:"updated_at,omitempty"`
+	Name                 string                 `json:"name,omitempty"`
+	Body                 string                 `json:"body"`
+	Status               string                 `json:"status,omitempty"`
+	Impact               string                 `json:"impact,omitempty"`
+	ImpactOverride       string                 `json:"impact_override,omitempty"`
+	Shortlink            string                 `json:"shortlink,omitempty"`
+	MonitoringAt         *Timestamp             `json:"monitoring_at,omitempty"`
+	ResolvedAt           *Timestamp             `json:"resolved_at,omitempty"`
+	ScheduledFor         *Timestamp             `json:"scheduled_for,omitempty"`
+	ScheduledUntil       *Timestamp             `json:"scheduled_until,omitempty"`
+	Components           []Component            `json:"components,omitempty"`
+	ComponentIDs         []string               `json:"component_ids,omitempty"`
+	IncidentUpdates      []IncidentHistoryEntry `json:"incident_updates,omitempty"`
+	DeliverNotifications bool                   `json:"deliver_notifications"`
+	Metadata             IncidentMetadata       `json:"metadata,omitempty"`
 }
 
-type Incident struct {
-	ID                   string                      `json:"id,omitempty"`
-	PageID               string                      `json:"page_id,omitempty"`
-	CreatedAt            Timestamp                   `json:"created_at,omitempty"`
-	UpdatedAt            Timestamp                   `json:"updated_at,omitempty"`
-	Name                 string                      `json:"name,omitempty"`
-	Body                 string                      `json:"body"`
-	Status               string                      `json:"status,omitempty"`
-	Components           []Component                 `json:"components,omitempty"`
-	ComponentIDs         []string                    `json:"component_ids,omitempty"`
-	DeliverNotifications bool                        `json:"deliver_notifications"`
-	Metadata             map[string]IncidentMetadata `json:"metadata,omitempty"`
+// IncidentHistoryEntry is a single posted update in an incident's timeline
+type IncidentHistoryEntry struct {
+	ID         string     `json:"id,omitempty"`
+	IncidentID string     `json:"incident_id,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	Body       string     `json:"body,omitempty"`
+	CreatedAt  Timestamp  `json:"created_at,omitempty"`
+	UpdatedAt  Timestamp  `json:"updated_at,omitempty"`
+	DisplayAt  *Timestamp `json:"display_at,omitempty"`
 }
 
+// IncidentUpdate is the incident create and update request representation.
+// Fields that are not set are left out of the request.
 type IncidentUpdate struct {
-	ID                   string                      `json:"id,omitempty"`
-	PageID               string                      `json:"page_id,omitempty"`
-	CreatedAt            Timestamp                   `json:"created_at,omitempty"`
-	UpdatedAt            Timestamp                   `json:"updated_at,omitempty"`
-	Name                 string                      `json:"name,omitempty"`
-	Body                 string                      `json:"body"`
-	Status               string                      `json:"status,omitempty"`
-	Components           map[string]string           `json:"components,omitempty"`
-	ComponentIDs         []string                    `json:"component_ids,omitempty"`
-	DeliverNotifications bool                        `json:"deliver_notifications"`
-	Metadata             map[string]IncidentMetadata `json:"metadata,omitempty"`
+	ID                               string              `json:"id,omitempty"`
+	PageID                           string              `json:"page_id,omitempty"`
+	CreatedAt                        *Timestamp          `json:"created_at,omitempty"`
+	UpdatedAt                        *Timestamp          `json:"updated_at,omitempty"`
+	Name                             Nullable[string]    `json:"name,omitempty"`
+	Body                             Nullable[string]    `json:"body,omitempty"`
+	Status                           Nullable[string]    `json:"status,omitempty"`
+	ImpactOverride                   Nullable[string]    `json:"impact_override,omitempty"`
+	ScheduledFor                     Nullable[Timestamp] `json:"scheduled_for,omitempty"`
+	ScheduledUntil                   Nullable[Timestamp] `json:"scheduled_until,omitempty"`
+	ScheduledRemindPrior             Nullable[bool]      `json:"scheduled_remind_prior,omitempty"`
+	ScheduledAutoInProgress          Nullable[bool]      `json:"scheduled_auto_in_progress,omitempty"`
+	ScheduledAutoCompleted           Nullable[bool]      `json:"scheduled_auto_completed,omitempty"`
+	AutoTransitionToMaintenanceState Nullable[bool]      `json:"auto_transition_to_maintenance_state,omitempty"`
+	AutoTransitionToOperationalState Nullable[bool]      `json:"auto_transition_to_operational_state,omitempty"`
+	Components                       map[string]string   `json:"components,omitempty"`
+	ComponentIDs                     []string            `json:"component_ids,omitempty"`
+	DeliverNotifications             Nullable[bool]      `json:"deliver_notifications,omitempty"`
+	Metadata                         IncidentMetadata    `json:"metadata,omitempty"`
 }
 
-// CreateIncident creates a new incident
-func (s *IncidentService) CreateIncident(ctx context.Context, pageID, status string, incident Incident) (*Incident, error) {
+// componentIDs returns the ids of the components named in the request
+func (i *IncidentUpdate) componentIDs() []string {
+	ids := append([]string(nil), i.ComponentIDs...)
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	return ids
+}
 
+// CreateIncident creates a new incident from a request, usually the result
+// of IncidentBuilder.Build
+func (s *IncidentService) CreateIncident(ctx context.Context, pageID string, incident *IncidentUpdate) (*Incident, error) {
 	if pageID == "" {
 		pageID = s.client.defaultPage
 	}
 
-	componentMap := make(map[string]string, 0)
+	path := "v1/pages/" + pageID + "/incidents"
+	payload := UpdateIncidentRequestBody{Incident: *incident}
+	req, err := s.client.newRequest("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "IncidentService.CreateIncident", pageID, incident.componentIDs()...)
+	var createdIncident Incident
+	_, err = s.client.do(ctx, req, &createdIncident)
+
+	return &createdIncident, end(err)
+}
 
-	for _, c := range incident.ComponentIDs {
-		componentMap[c] = status
+// UpdateIncident applies a request, usually the result of
+// IncidentBuilder.Build, to an existing incident. Fields that are not set
+// in the request are left unchanged.
+func (s *IncidentService) UpdateIncident(ctx context.Context, pageID, incidentID string, incident *IncidentUpdate) (*Incident, error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
 	}
 
-	updateBody := IncidentUpdate{
-		ID:                   incident.ID,
-		Name:                 incident.Name,
-		Body:                 incident.Body,
-		Components:           componentMap,
-		ComponentIDs:         incident.ComponentIDs,
-		Status:               incident.Status,
-		DeliverNotifications: incident.DeliverNotifications,
-		Metadata:             incident.Metadata,
+	path := "v1/pages/" + pageID + "/incidents/" + incidentID
+	payload := UpdateIncidentRequestBody{Incident: *incident}
+	req, err := s.client.newRequest("PATCH", path, payload)
+	if err != nil {
+		return nil, err
 	}
 
-	path := "v1/pages/" + pageID + "/incidents/"
-	requestBody := UpdateIncidentRequestBody{updateBody}
+	ctx, end := s.client.startOperation(ctx, "IncidentService.UpdateIncident", pageID, incident.componentIDs()...)
+	var updatedIncident Incident
+	_, err = s.client.do(ctx, req, &updatedIncident)
 
-	req, err := s.client.newRequest("POST", path, requestBody)
+	return &updatedIncident, end(err)
+}
 
+// ListIncidentsOptions specifies the optional parameters to the ListIncidents method
+type ListIncidentsOptions struct {
+	// Query is a free text search over incident names and updates.
+	Query string
+	ListOptions
+}
+
+// ListIncidents returns a list of incidents for a given page id, most recent first
+func (s *IncidentService) ListIncidents(ctx context.Context, pageID string, opts *ListIncidentsOptions) ([]Incident, error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	path := "v1/pages/" + pageID + "/incidents"
+	if opts != nil {
+		q := opts.ListOptions.values()
+		if opts.Query != "" {
+			q.Set("q", opts.Query)
+		}
+		path = addQuery(path, q)
+	}
+
+	req, err := s.client.newRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.client.do(ctx, req, &incident)
+	ctx, end := s.client.startOperation(ctx, "IncidentService.ListIncidents", pageID)
+	var incidents []Incident
+	_, err = s.client.do(ctx, req, &incidents)
 
-	return &incident, err
+	return incidents, end(err)
+}
+
+// ListUnresolvedIncidents returns a list of unresolved incidents for a given page id
+func (s *IncidentService) ListUnresolvedIncidents(ctx context.Context, pageID string, opts *ListOptions) ([]Incident, error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	path := addOptions("v1/pages/"+pageID+"/incidents/unresolved", opts)
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "IncidentService.ListUnresolvedIncidents", pageID)
+	var incidents []Incident
+	_, err = s.client.do(ctx, req, &incidents)
+
+	return incidents, end(err)
+}
+
+// listAllUnresolvedIncidents returns every unresolved incident of a given
+// page id, following pagination
+func (s *IncidentService) listAllUnresolvedIncidents(ctx context.Context, pageID string) ([]Incident, error) {
+	opts := &ListOptions{Page: 1, PerPage: 100}
+	all := make([]Incident, 0)
+
+	for {
+		incidents, err := s.ListUnresolvedIncidents(ctx, pageID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, incidents...)
+		if len(incidents) < opts.PerPage {
+			return all, nil
+		}
+		opts.Page++
+	}
 }
 
 // GetGroup returns component group information for a given page and component group id
@@ -113,10 +237,11 @@ func (s *IncidentService) GetIncident(ctx context.Context, pageID string, incide
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "IncidentService.GetIncident", pageID)
 	var incident Incident
 	_, err = s.client.do(ctx, req, &incident)
 
-	return &incident, err
+	return &incident, end(err)
 }
 
 // UpdateIncidentComponentStatus updates a component for a given page and component id
@@ -132,29 +257,30 @@ func (s *IncidentService) UpdateIncidentComponentStatus(ctx context.Context, pag
 
 	for _, c := range incident.Components {
 		componentMap[c.ID] = status
-		fmt.Printf("COMP %s STATUS %s\n", c.ID, status)
 	}
 
 	updateBody := IncidentUpdate{
 		ID:                   incident.ID,
-		Name:                 incident.Name,
-		Body:                 incident.Body,
+		Name:                 nonZero(incident.Name),
+		Body:                 Value(incident.Body),
 		Components:           componentMap,
 		ComponentIDs:         incident.ComponentIDs,
-		Status:               incident.Status,
-		DeliverNotifications: incident.DeliverNotifications,
+		Status:               nonZero(incident.Status),
+		DeliverNotifications: Value(incident.DeliverNotifications),
+		Metadata:             incident.Metadata,
 	}
 
 	payload := UpdateIncidentRequestBody{Incident: updateBody}
-	req, err := s.client.newRequest("PUT", path, payload)
+	req, err := s.client.newRequest("PATCH", path, payload)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "IncidentService.UpdateIncidentComponentStatus", pageID, updateBody.componentIDs()...)
 	var updatedIncident Incident
 	_, err = s.client.do(ctx, req, &updatedIncident)
 
-	return &updatedIncident, err
+	return &updatedIncident, end(err)
 }
 
 // UpdateIncidentStatus updates the status of a given incident ID for a given page
@@ -177,23 +303,25 @@ func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, pageID, stat
 
 	updateBody := IncidentUpdate{
 		ID:                   incident.ID,
-		Name:                 incident.Name,
-		Body:                 body,
+		Name:                 nonZero(incident.Name),
+		Body:                 Value(body),
 		Components:           componentMap,
 		ComponentIDs:         incident.ComponentIDs,
-		Status:               status,
-		DeliverNotifications: incident.DeliverNotifications,
+		Status:               nonZero(status),
+		DeliverNotifications: Value(incident.DeliverNotifications),
+		Metadata:             incident.Metadata,
 	}
 
 	payload := UpdateIncidentRequestBody{Incident: updateBody}
-	req, err := s.client.newRequest("PUT", path, payload)
+	req, err := s.client.newRequest("PATCH", path, payload)
 
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "IncidentService.UpdateIncidentStatus", pageID, updateBody.componentIDs()...)
 	var updatedIncident Incident
 	_, err = s.client.do(ctx, req, &updatedIncident)
 
-	return &updatedIncident, err
+	return &updatedIncident, end(err)
 }
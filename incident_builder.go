@@ -0,0 +1,221 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	realtimeIncidentStatuses  = []string{StatusInvestigating, StatusIdentified, StatusMonitoring, StatusResolved}
+	scheduledIncidentStatuses = []string{StatusScheduled, StatusInProgress, StatusVerifying, StatusCompleted}
+	componentStatuses         = []string{StatusOperational, StatusMaintenance, StatusDegraded, StatusPartialOutage, StatusMajorOutage}
+	impacts                   = []string{ImpactNone, ImpactMinor, ImpactMajor, ImpactCritical, ImpactMaintenance}
+)
+
+func oneOf(v string, allowed []string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+// IncidentValidationError lists the problems that stop an incident from
+// being accepted by the Statuspage API
+type IncidentValidationError struct {
+	Problems []string
+}
+
+func (e *IncidentValidationError) Error() string {
+	return "invalid incident: " + strings.Join(e.Problems, "; ")
+}
+
+// IncidentBuilder assembles an incident request step by step, with a
+// status per component. Build checks the combinations the API rejects
+// before anything is sent, and its result is accepted by CreateIncident and
+// UpdateIncident.
+type IncidentBuilder struct {
+	incident IncidentUpdate
+}
+
+// NewIncidentBuilder returns a builder for an incident with the given name
+func NewIncidentBuilder(name string) *IncidentBuilder {
+	return &IncidentBuilder{incident: IncidentUpdate{Name: nonZero(name)}}
+}
+
+// Name sets the incident name
+func (b *IncidentBuilder) Name(name string) *IncidentBuilder {
+	b.incident.Name = Value(name)
+	return b
+}
+
+// Body sets the message of the incident update
+func (b *IncidentBuilder) Body(body string) *IncidentBuilder {
+	b.incident.Body = Value(body)
+	return b
+}
+
+// Status sets the incident status
+func (b *IncidentBuilder) Status(status string) *IncidentBuilder {
+	b.incident.Status = Value(status)
+	return b
+}
+
+// ImpactOverride sets the impact shown instead of the one Statuspage derives
+// from the component statuses
+func (b *IncidentBuilder) ImpactOverride(impact string) *IncidentBuilder {
+	b.incident.ImpactOverride = Value(impact)
+	return b
+}
+
+// Component adds a component to the incident with its own status
+func (b *IncidentBuilder) Component(componentID, status string) *IncidentBuilder {
+	if b.incident.Components == nil {
+		b.incident.Components = make(map[string]string)
+	}
+	if _, ok := b.incident.Components[componentID]; !ok {
+		b.incident.ComponentIDs = append(b.incident.ComponentIDs, componentID)
+	}
+	b.incident.Components[componentID] = status
+	return b
+}
+
+// Metadata sets a metadata value under a namespace and key
+func (b *IncidentBuilder) Metadata(namespace, key string, value interface{}) *IncidentBuilder {
+	b.incident.Metadata.Set(namespace, key, value)
+	return b
+}
+
+// DeliverNotifications sets whether subscribers are notified
+func (b *IncidentBuilder) DeliverNotifications(deliver bool) *IncidentBuilder {
+	b.incident.DeliverNotifications = Value(deliver)
+	return b
+}
+
+// Scheduled turns the incident into a scheduled maintenance running from
+// start to end. The status defaults to StatusScheduled.
+func (b *IncidentBuilder) Scheduled(start, end time.Time) *IncidentBuilder {
+	b.incident.ScheduledFor = Value(Timestamp{start})
+	b.incident.ScheduledUntil = Value(Timestamp{end})
+	return b
+}
+
+// RemindPrior sets whether subscribers are reminded an hour before a
+// scheduled maintenance starts
+func (b *IncidentBuilder) RemindPrior(remind bool) *IncidentBuilder {
+	b.incident.ScheduledRemindPrior = Value(remind)
+	return b
+}
+
+// AutoTransition sets whether a scheduled maintenance moves to in progress
+// and completed on its own, and whether its components follow along
+func (b *IncidentBuilder) AutoTransition(auto bool) *IncidentBuilder {
+	b.incident.ScheduledAutoInProgress = Value(auto)
+	b.incident.ScheduledAutoCompleted = Value(auto)
+	b.incident.AutoTransitionToMaintenanceState = Value(auto)
+	b.incident.AutoTransitionToOperationalState = Value(auto)
+	return b
+}
+
+// Build validates the incident and returns the request to send. The
+// returned error is an *IncidentValidationError listing every problem found.
+func (b *IncidentBuilder) Build() (*IncidentUpdate, error) {
+	return b.build(true)
+}
+
+// build validates the incident; the name is only required for new incidents
+func (b *IncidentBuilder) build(create bool) (*IncidentUpdate, error) {
+	incident := b.incident
+	start, scheduled := incident.ScheduledFor.Get()
+	end, _ := incident.ScheduledUntil.Get()
+	name, _ := incident.Name.Get()
+	status, _ := incident.Status.Get()
+	impact, _ := incident.ImpactOverride.Get()
+
+	incident.ComponentIDs = append([]string(nil), b.incident.ComponentIDs...)
+	if b.incident.Components != nil {
+		incident.Components = make(map[string]string, len(b.incident.Components))
+		for id, status := range b.incident.Components {
+			incident.Components[id] = status
+		}
+	}
+	incident.Metadata = b.incident.Metadata.Copy()
+
+	if scheduled && status == "" {
+		status = StatusScheduled
+		incident.Status = Value(status)
+	}
+
+	problems := make([]string, 0)
+
+	if create && name == "" {
+		problems = append(problems, "name is required")
+	}
+
+	switch {
+	case status == "":
+	case scheduled && !oneOf(status, scheduledIncidentStatuses):
+		problems = append(problems, fmt.Sprintf("status %q is not valid for a scheduled maintenance", status))
+	case !scheduled && oneOf(status, scheduledIncidentStatuses):
+		problems = append(problems, fmt.Sprintf("status %q requires a scheduled window", status))
+	case !scheduled && !oneOf(status, realtimeIncidentStatuses):
+		problems = append(problems, fmt.Sprintf("unknown incident status %q", status))
+	}
+
+	if impact != "" && !oneOf(impact, impacts) {
+		problems = append(problems, fmt.Sprintf("unknown impact %q", impact))
+	}
+	if !scheduled && impact == ImpactMaintenance {
+		problems = append(problems, "impact \"maintenance\" requires a scheduled window")
+	}
+
+	if scheduled && !end.After(start.Time) {
+		problems = append(problems, "scheduled window must end after it starts")
+	}
+
+	ids := make([]string, 0, len(incident.Components))
+	for id := range incident.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		status := incident.Components[id]
+		switch {
+		case !oneOf(status, componentStatuses):
+			problems = append(problems, fmt.Sprintf("unknown status %q for component %s", status, id))
+		case status == StatusMaintenance && !scheduled:
+			problems = append(problems, fmt.Sprintf("component %s cannot be under maintenance in a realtime incident", id))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &IncidentValidationError{Problems: problems}
+	}
+
+	return &incident, nil
+}
+
+// CreateIncidentWith validates the incident in b and creates it
+func (s *IncidentService) CreateIncidentWith(ctx context.Context, pageID string, b *IncidentBuilder) (*Incident, error) {
+	incident, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateIncident(ctx, pageID, incident)
+}
+
+// UpdateIncidentWith validates the incident in b and applies it to an
+// existing incident id. The builder's name may be left empty.
+func (s *IncidentService) UpdateIncidentWith(ctx context.Context, pageID, incidentID string, b *IncidentBuilder) (*Incident, error) {
+	incident, err := b.build(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdateIncident(ctx, pageID, incidentID, incident)
+}
@@ -0,0 +1,164 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestIncidentBuilder_Build(t *testing.T) {
+	incident, err := statuspage.NewIncidentBuilder("API errors").
+		Body("We are looking into it").
+		Status(statuspage.StatusInvestigating).
+		ImpactOverride(statuspage.ImpactMajor).
+		Component("api", statuspage.StatusMajorOutage).
+		Component("web", statuspage.StatusDegraded).
+		DeliverNotifications(true).
+		Build()
+	if err != nil {
+		t.Fatalf("IncidentBuilder.Build returned error: %v", err)
+	}
+
+	want := &statuspage.IncidentUpdate{
+		Name:                 statuspage.Value("API errors"),
+		Body:                 statuspage.Value("We are looking into it"),
+		Status:               statuspage.Value(statuspage.StatusInvestigating),
+		ImpactOverride:       statuspage.Value(statuspage.ImpactMajor),
+		Components:           map[string]string{"api": statuspage.StatusMajorOutage, "web": statuspage.StatusDegraded},
+		ComponentIDs:         []string{"api", "web"},
+		DeliverNotifications: statuspage.Value(true),
+	}
+	if !reflect.DeepEqual(incident, want) {
+		t.Errorf("IncidentBuilder.Build returned %+v, want %+v", incident, want)
+	}
+}
+
+func TestIncidentBuilder_Build_scheduled(t *testing.T) {
+	incident, err := statuspage.NewIncidentBuilder("Database upgrade").
+		Scheduled(referenceTime, referenceTime.Add(2*time.Hour)).
+		Component("db", statuspage.StatusMaintenance).
+		AutoTransition(true).
+		Build()
+	if err != nil {
+		t.Fatalf("IncidentBuilder.Build returned error: %v", err)
+	}
+
+	if status, _ := incident.Status.Get(); status != statuspage.StatusScheduled {
+		t.Errorf("IncidentBuilder.Build status = %q, want %q", status, statuspage.StatusScheduled)
+	}
+	if auto, _ := incident.ScheduledAutoInProgress.Get(); !auto {
+		t.Errorf("IncidentBuilder.Build did not set auto transitions: %+v", incident)
+	}
+	if auto, _ := incident.AutoTransitionToOperationalState.Get(); !auto {
+		t.Errorf("IncidentBuilder.Build did not set auto transitions: %+v", incident)
+	}
+}
+
+func TestIncidentBuilder_Build_invalid(t *testing.T) {
+	_, err := statuspage.NewIncidentBuilder("").
+		Status(statuspage.StatusInProgress).
+		ImpactOverride("catastrophic").
+		Component("api", "broken").
+		Component("db", statuspage.StatusMaintenance).
+		Build()
+
+	verr, ok := err.(*statuspage.IncidentValidationError)
+	if !ok {
+		t.Fatalf("IncidentBuilder.Build returned %v, want *IncidentValidationError", err)
+	}
+
+	want := []string{
+		"name is required",
+		`status "in_progress" requires a scheduled window`,
+		`unknown impact "catastrophic"`,
+		`unknown status "broken" for component api`,
+		"component db cannot be under maintenance in a realtime incident",
+	}
+	if !reflect.DeepEqual(verr.Problems, want) {
+		t.Errorf("IncidentValidationError.Problems = %q, want %q", verr.Problems, want)
+	}
+
+	_, err = statuspage.NewIncidentBuilder("Backwards").
+		Scheduled(referenceTime, referenceTime).
+		Status(statuspage.StatusInvestigating).
+		Build()
+	if err == nil {
+		t.Error("IncidentBuilder.Build with realtime status and empty window expected error")
+	}
+}
+
+func TestIncidentService_CreateIncidentWith(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		want := map[string]string{"api": statuspage.StatusMajorOutage, "web": statuspage.StatusDegraded}
+		if !reflect.DeepEqual(v.Incident.Components, want) {
+			t.Errorf("Request components = %+v, want %+v", v.Incident.Components, want)
+		}
+
+		fmt.Fprint(w, `{"id":"i1","name":"API errors"}`)
+	})
+
+	b := statuspage.NewIncidentBuilder("API errors").
+		Status(statuspage.StatusIdentified).
+		Component("api", statuspage.StatusMajorOutage).
+		Component("web", statuspage.StatusDegraded)
+
+	incident, err := client.Incident.CreateIncidentWith(context.Background(), "1", b)
+	if err != nil {
+		t.Errorf("IncidentService.CreateIncidentWith returned error: %v", err)
+	}
+
+	want := &statuspage.Incident{ID: "i1", Name: "API errors"}
+	if !reflect.DeepEqual(incident, want) {
+		t.Errorf("IncidentService.CreateIncidentWith returned %+v, want %+v", incident, want)
+	}
+}
+
+func TestIncidentService_UpdateIncidentWith(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		body, _ := io.ReadAll(r.Body)
+		want := `{"incident":{"status":"monitoring"}}` + "\n"
+		if string(body) != want {
+			t.Errorf("Request body = %s, want %s", body, want)
+		}
+
+		fmt.Fprint(w, `{"id":"i1","status":"monitoring"}`)
+	})
+
+	b := statuspage.NewIncidentBuilder("").Status(statuspage.StatusMonitoring)
+	if _, err := client.Incident.UpdateIncidentWith(context.Background(), "1", "i1", b); err != nil {
+		t.Errorf("IncidentService.UpdateIncidentWith returned error: %v", err)
+	}
+}
+
+func TestIncidentService_CreateIncidentWith_invalid(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid incident should not be sent")
+	})
+
+	b := statuspage.NewIncidentBuilder("Bad").Component("api", "on_fire")
+	if _, err := client.Incident.CreateIncidentWith(context.Background(), "1", b); err == nil {
+		t.Error("IncidentService.CreateIncidentWith expected error")
+	}
+}
@@ -0,0 +1,103 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+)
+
+// incidentTransitions lists the statuses a realtime incident may move to from
+// each status. An incident may always post another update in its current
+// status, except once resolved.
+var incidentTransitions = map[string][]string{
+	"":                  {StatusInvestigating, StatusIdentified},
+	StatusInvestigating: {StatusInvestigating, StatusIdentified, StatusResolved},
+	StatusIdentified:    {StatusIdentified, StatusMonitoring, StatusResolved},
+	StatusMonitoring:    {StatusMonitoring, StatusIdentified, StatusResolved},
+	StatusResolved:      {},
+}
+
+// CanTransition reports whether a realtime incident may move from one status to another
+func CanTransition(from, to string) bool {
+	return oneOf(to, incidentTransitions[from])
+}
+
+// InvalidTransitionError is returned when an incident update would move an
+// incident to a status it may not reach from its current one
+type InvalidTransitionError struct {
+	IncidentID string
+	From       string
+	To         string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("incident %s cannot move from %q to %q", e.IncidentID, e.From, e.To)
+}
+
+// IncidentLifecycle moves realtime incidents through their statuses, refusing
+// transitions that skip a step or leave the resolved state. Resolving an
+// incident also restores its components to StatusOperational.
+type IncidentLifecycle struct {
+	service *IncidentService
+	pageID  string
+
+	// DeliverNotifications sets whether subscribers are notified of each update.
+	DeliverNotifications bool
+}
+
+// Lifecycle returns an IncidentLifecycle for the incidents of a given page id
+func (s *IncidentService) Lifecycle(pageID string) *IncidentLifecycle {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	return &IncidentLifecycle{service: s, pageID: pageID}
+}
+
+// Acknowledge posts an investigating update to an incident
+func (l *IncidentLifecycle) Acknowledge(ctx context.Context, incidentID, body string) (*Incident, error) {
+	return l.transition(ctx, "Acknowledge", incidentID, StatusInvestigating, body)
+}
+
+// Identify posts an identified update to an incident
+func (l *IncidentLifecycle) Identify(ctx context.Context, incidentID, body string) (*Incident, error) {
+	return l.transition(ctx, "Identify", incidentID, StatusIdentified, body)
+}
+
+// Monitor posts a monitoring update to an incident
+func (l *IncidentLifecycle) Monitor(ctx context.Context, incidentID, body string) (*Incident, error) {
+	return l.transition(ctx, "Monitor", incidentID, StatusMonitoring, body)
+}
+
+// Resolve resolves an incident and restores its components to StatusOperational
+func (l *IncidentLifecycle) Resolve(ctx context.Context, incidentID, body string) (*Incident, error) {
+	return l.transition(ctx, "Resolve", incidentID, StatusResolved, body)
+}
+
+// transition moves an incident to status to, reported as operation of the
+// IncidentLifecycle
+func (l *IncidentLifecycle) transition(ctx context.Context, operation, incidentID, to, body string) (_ *Incident, err error) {
+	ctx, end := l.service.client.startOperation(ctx, "IncidentLifecycle."+operation, l.pageID)
+	defer func() { end(err) }()
+
+	current, err := l.service.GetIncident(ctx, l.pageID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !CanTransition(current.Status, to) {
+		return nil, &InvalidTransitionError{IncidentID: incidentID, From: current.Status, To: to}
+	}
+
+	b := NewIncidentBuilder("").
+		Status(to).
+		Body(body).
+		DeliverNotifications(l.DeliverNotifications)
+
+	if to == StatusResolved {
+		for _, c := range current.Components {
+			b.Component(c.ID, StatusOperational)
+		}
+	}
+
+	return l.service.UpdateIncidentWith(ctx, l.pageID, incidentID, b)
+}
@@ -0,0 +1,97 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{statuspage.StatusInvestigating, statuspage.StatusIdentified, true},
+		{statuspage.StatusInvestigating, statuspage.StatusMonitoring, false},
+		{statuspage.StatusIdentified, statuspage.StatusMonitoring, true},
+		{statuspage.StatusMonitoring, statuspage.StatusIdentified, true},
+		{statuspage.StatusMonitoring, statuspage.StatusResolved, true},
+		{statuspage.StatusResolved, statuspage.StatusInvestigating, false},
+		{statuspage.StatusResolved, statuspage.StatusResolved, false},
+	}
+
+	for _, tt := range tests {
+		if got := statuspage.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestIncidentLifecycle_Resolve(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"i1","status":"monitoring","components":[{"id":"api","status":"major_outage"},{"id":"web","status":"degraded_performance"}]}`)
+		case "PATCH":
+			v := &statuspage.UpdateIncidentRequestBody{}
+			json.NewDecoder(r.Body).Decode(v)
+
+			want := statuspage.IncidentUpdate{
+				Body:                 statuspage.Value("Fixed"),
+				Status:               statuspage.Value(statuspage.StatusResolved),
+				Components:           map[string]string{"api": statuspage.StatusOperational, "web": statuspage.StatusOperational},
+				ComponentIDs:         []string{"api", "web"},
+				DeliverNotifications: statuspage.Value(true),
+			}
+			if !reflect.DeepEqual(v.Incident, want) {
+				t.Errorf("Request body = %+v, want %+v", v.Incident, want)
+			}
+
+			fmt.Fprint(w, `{"id":"i1","status":"resolved"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	lifecycle := client.Incident.Lifecycle("1")
+	lifecycle.DeliverNotifications = true
+
+	incident, err := lifecycle.Resolve(context.Background(), "i1", "Fixed")
+	if err != nil {
+		t.Fatalf("IncidentLifecycle.Resolve returned error: %v", err)
+	}
+
+	if incident.Status != statuspage.StatusResolved {
+		t.Errorf("IncidentLifecycle.Resolve returned status %q, want %q", incident.Status, statuspage.StatusResolved)
+	}
+}
+
+func TestIncidentLifecycle_invalidTransition(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"i1","status":"resolved"}`)
+	})
+
+	_, err := client.Incident.Lifecycle("1").Acknowledge(context.Background(), "i1", "Looking again")
+
+	terr, ok := err.(*statuspage.InvalidTransitionError)
+	if !ok {
+		t.Fatalf("IncidentLifecycle.Acknowledge returned %v, want *InvalidTransitionError", err)
+	}
+
+	want := &statuspage.InvalidTransitionError{IncidentID: "i1", From: statuspage.StatusResolved, To: statuspage.StatusInvestigating}
+	if !reflect.DeepEqual(terr, want) {
+		t.Errorf("IncidentLifecycle.Acknowledge error = %+v, want %+v", terr, want)
+	}
+}
@@ -0,0 +1,276 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// maxModifyAttempts is how many times ModifyIncident re-reads an incident
+// that changed underneath it before giving up
+const maxModifyAttempts = 3
+
+// IncidentPatch is a partial incident update. Only the fields that are set
+// are sent, so everything else on the incident is left as it is.
+type IncidentPatch struct {
+	Name                 Nullable[string]           `json:"name,omitempty"`
+	Status               Nullable[string]           `json:"status,omitempty"`
+	Body                 Nullable[string]           `json:"body,omitempty"`
+	ImpactOverride       Nullable[string]           `json:"impact_override,omitempty"`
+	ScheduledFor         Nullable[Timestamp]        `json:"scheduled_for,omitempty"`
+	ScheduledUntil       Nullable[Timestamp]        `json:"scheduled_until,omitempty"`
+	DeliverNotifications Nullable[bool]             `json:"deliver_notifications,omitempty"`
+	Components           map[string]string          `json:"components,omitempty"`
+	ComponentIDs         []string                   `json:"component_ids,omitempty"`
+	Metadata             Nullable[IncidentMetadata] `json:"metadata,omitempty"`
+}
+
+// PatchIncidentRequestBody is the patch incident request body representation
+type PatchIncidentRequestBody struct {
+	Incident IncidentPatch `json:"incident"`
+}
+
+// IsEmpty reports whether p changes nothing
+func (p IncidentPatch) IsEmpty() bool {
+	return reflect.DeepEqual(p, IncidentPatch{})
+}
+
+// componentIDs returns the ids of the components named in the patch
+func (p IncidentPatch) componentIDs() []string {
+	ids := append([]string(nil), p.ComponentIDs...)
+	for id := range p.Components {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ConcurrentModificationError is returned by ModifyIncident when the incident
+// kept changing between being read and being written
+type ConcurrentModificationError struct {
+	IncidentID string
+	Expected   Timestamp
+	Actual     Timestamp
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("incident %s was modified concurrently: expected updated_at %s, got %s", e.IncidentID, e.Expected, e.Actual)
+}
+
+// PatchIncident applies a partial update to an incident
+func (s *IncidentService) PatchIncident(ctx context.Context, pageID, incidentID string, patch IncidentPatch) (*Incident, error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	path := "v1/pages/" + pageID + "/incidents/" + incidentID
+	payload := PatchIncidentRequestBody{Incident: patch}
+	req, err := s.client.newRequest("PATCH", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "IncidentService.PatchIncident", pageID, patch.componentIDs()...)
+	var incident Incident
+	_, err = s.client.do(ctx, req, &incident)
+
+	return &incident, end(err)
+}
+
+// ModifyIncident fetches an incident, passes a copy of it to mutate and
+// sends only the fields mutate changed. The copy's ComponentIDs is filled in
+// from its Components, so ids can be appended to or removed from it. An
+// error from mutate aborts the update. When mutate changes nothing, the
+// current incident is returned without sending a request.
+//
+// Before sending, the incident is read again and, if someone else updated it
+// in the meantime as seen from its UpdatedAt, mutate is run again on the
+// fresh incident; after a few attempts a *ConcurrentModificationError is
+// returned. The API has no conditional update, so this check is best effort:
+// a change made between the second read and the write is not detected.
+func (s *IncidentService) ModifyIncident(ctx context.Context, pageID, incidentID string, mutate func(*Incident) error) (_ *Incident, err error) {
+	ctx, end := s.client.startOperation(ctx, "IncidentService.ModifyIncident", pageID)
+	defer func() { end(err) }()
+
+	current, err := s.GetIncident(ctx, pageID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		seedComponentIDs(current)
+
+		modified := copyIncident(*current)
+		if err := mutate(&modified); err != nil {
+			return nil, err
+		}
+
+		patch := DiffIncident(*current, modified)
+		if patch.IsEmpty() {
+			return current, nil
+		}
+
+		latest, err := s.GetIncident(ctx, pageID, incidentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if latest.UpdatedAt.Equal(current.UpdatedAt) {
+			return s.PatchIncident(ctx, pageID, incidentID, patch)
+		}
+
+		if attempt == maxModifyAttempts {
+			return nil, &ConcurrentModificationError{IncidentID: incidentID, Expected: current.UpdatedAt, Actual: latest.UpdatedAt}
+		}
+		current = latest
+	}
+}
+
+// DiffIncident returns the patch that turns before into after. Components
+// are compared by id and status; metadata is replaced as a whole when it
+// differs.
+func DiffIncident(before, after Incident) IncidentPatch {
+	var p IncidentPatch
+
+	if after.Name != before.Name {
+		p.Name = Value(after.Name)
+	}
+	if after.Status != before.Status {
+		p.Status = Value(after.Status)
+	}
+	if after.Body != before.Body {
+		p.Body = Value(after.Body)
+	}
+	if after.ImpactOverride != before.ImpactOverride {
+		p.ImpactOverride = diffString(after.ImpactOverride)
+	}
+	if !equalTimestamps(after.ScheduledFor, before.ScheduledFor) {
+		p.ScheduledFor = diffTimestamp(after.ScheduledFor)
+	}
+	if !equalTimestamps(after.ScheduledUntil, before.ScheduledUntil) {
+		p.ScheduledUntil = diffTimestamp(after.ScheduledUntil)
+	}
+	if after.DeliverNotifications != before.DeliverNotifications {
+		p.DeliverNotifications = Value(after.DeliverNotifications)
+	}
+
+	beforeStatuses := statusesByComponent(before.Components)
+	for id, status := range statusesByComponent(after.Components) {
+		if beforeStatuses[id] != status {
+			if p.Components == nil {
+				p.Components = make(map[string]string)
+			}
+			p.Components[id] = status
+		}
+	}
+
+	// Components added to Components alone are affected too.
+	afterIDs := append([]string(nil), incidentComponentIDs(after)...)
+	listed := toSet(afterIDs)
+	for _, c := range after.Components {
+		if _, ok := beforeStatuses[c.ID]; !ok && !listed[c.ID] {
+			afterIDs = append(afterIDs, c.ID)
+			listed[c.ID] = true
+		}
+	}
+	if !reflect.DeepEqual(toSet(afterIDs), toSet(incidentComponentIDs(before))) {
+		p.ComponentIDs = afterIDs
+	}
+
+	if (len(after.Metadata) > 0 || len(before.Metadata) > 0) && !reflect.DeepEqual(after.Metadata, before.Metadata) {
+		if len(after.Metadata) == 0 {
+			p.Metadata = Null[IncidentMetadata]()
+		} else {
+			p.Metadata = Value(after.Metadata)
+		}
+	}
+
+	return p
+}
+
+func diffString(s string) Nullable[string] {
+	if s == "" {
+		return Null[string]()
+	}
+	return Value(s)
+}
+
+func diffTimestamp(t *Timestamp) Nullable[Timestamp] {
+	if t == nil {
+		return Null[Timestamp]()
+	}
+	return Value(*t)
+}
+
+func equalTimestamps(a, b *Timestamp) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func statusesByComponent(components []Component) map[string]string {
+	statuses := make(map[string]string, len(components))
+	for _, c := range components {
+		statuses[c.ID] = c.Status
+	}
+	return statuses
+}
+
+// incidentComponentIDs returns the ids of the components affected by an
+// incident. Incidents read from the API only list their components, so the
+// ids are taken from those when ComponentIDs is empty.
+func incidentComponentIDs(incident Incident) []string {
+	if len(incident.ComponentIDs) > 0 {
+		return incident.ComponentIDs
+	}
+
+	ids := make([]string, 0, len(incident.Components))
+	for _, c := range incident.Components {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+// seedComponentIDs fills in ComponentIDs from Components, which is all the
+// API returns, so that a mutation adding an id keeps the existing ones
+func seedComponentIDs(incident *Incident) {
+	if len(incident.ComponentIDs) == 0 {
+		incident.ComponentIDs = incidentComponentIDs(*incident)
+	}
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// copyIncident returns a copy of incident that can be mutated without
+// changing the original. Nested metadata values are still shared.
+func copyIncident(incident Incident) Incident {
+	c := incident
+	c.Components = append([]Component(nil), incident.Components...)
+	c.ComponentIDs = append([]string(nil), incident.ComponentIDs...)
+	c.IncidentUpdates = append([]IncidentHistoryEntry(nil), incident.IncidentUpdates...)
+	c.Metadata = incident.Metadata.Copy()
+
+	if incident.MonitoringAt != nil {
+		t := *incident.MonitoringAt
+		c.MonitoringAt = &t
+	}
+	if incident.ResolvedAt != nil {
+		t := *incident.ResolvedAt
+		c.ResolvedAt = &t
+	}
+	if incident.ScheduledFor != nil {
+		t := *incident.ScheduledFor
+		c.ScheduledFor = &t
+	}
+	if incident.ScheduledUntil != nil {
+		t := *incident.ScheduledUntil
+		c.ScheduledUntil = &t
+	}
+	return c
+}
@@ -0,0 +1,169 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestDiffIncident(t *testing.T) {
+	before := statuspage.Incident{
+		Name:           "API errors",
+		Status:         statuspage.StatusInvestigating,
+		ImpactOverride: statuspage.ImpactMajor,
+		Components: []statuspage.Component{
+			{ID: "api", Status: statuspage.StatusMajorOutage},
+			{ID: "web", Status: statuspage.StatusDegraded},
+		},
+		Metadata: statuspage.IncidentMetadata{"jira": {"issue_key": "OPS-1"}},
+	}
+
+	after := before
+	after.Status = statuspage.StatusIdentified
+	after.ImpactOverride = ""
+	after.Components = []statuspage.Component{
+		{ID: "api", Status: statuspage.StatusPartialOutage},
+		{ID: "web", Status: statuspage.StatusDegraded},
+	}
+
+	want := statuspage.IncidentPatch{
+		Status:         statuspage.Value(statuspage.StatusIdentified),
+		ImpactOverride: statuspage.Null[string](),
+		Components:     map[string]string{"api": statuspage.StatusPartialOutage},
+	}
+	if got := statuspage.DiffIncident(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffIncident returned %+v, want %+v", got, want)
+	}
+
+	if got := statuspage.DiffIncident(before, before); !got.IsEmpty() {
+		t.Errorf("DiffIncident of identical incidents returned %+v, want empty", got)
+	}
+
+	before.ComponentIDs = []string{"api", "web"}
+	added := before
+	added.Components = append(append([]statuspage.Component(nil), before.Components...), statuspage.Component{ID: "db", Status: statuspage.StatusDegraded})
+	if got := statuspage.DiffIncident(before, added); !reflect.DeepEqual(got.ComponentIDs, []string{"api", "web", "db"}) {
+		t.Errorf("DiffIncident component ids = %v, want api, web and db", got.ComponentIDs)
+	}
+}
+
+func TestIncidentService_PatchIncident(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		body, _ := io.ReadAll(r.Body)
+		want := `{"incident":{"status":"identified","impact_override":null}}` + "\n"
+		if string(body) != want {
+			t.Errorf("Request body = %s, want %s", body, want)
+		}
+
+		fmt.Fprint(w, `{"id":"i1","status":"identified"}`)
+	})
+
+	patch := statuspage.IncidentPatch{
+		Status:         statuspage.Value(statuspage.StatusIdentified),
+		ImpactOverride: statuspage.Null[string](),
+	}
+	incident, err := client.Incident.PatchIncident(context.Background(), "1", "i1", patch)
+	if err != nil {
+		t.Errorf("IncidentService.PatchIncident returned error: %v", err)
+	}
+
+	want := &statuspage.Incident{ID: "i1", Status: statuspage.StatusIdentified}
+	if !reflect.DeepEqual(incident, want) {
+		t.Errorf("IncidentService.PatchIncident returned %+v, want %+v", incident, want)
+	}
+}
+
+func TestIncidentService_ModifyIncident(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"i1","status":"investigating","updated_at":"2006-01-02T15:04:05Z","components":[{"id":"api","status":"major_outage"}],"metadata":{"jira":{"issue_key":"OPS-1"}}}`)
+		case "PATCH":
+			body, _ := io.ReadAll(r.Body)
+			want := `{"incident":{"body":"Rolling back","components":{"api":"partial_outage"}}}` + "\n"
+			if string(body) != want {
+				t.Errorf("Request body = %s, want %s", body, want)
+			}
+			fmt.Fprint(w, `{"id":"i1"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	_, err := client.Incident.ModifyIncident(context.Background(), "1", "i1", func(incident *statuspage.Incident) error {
+		incident.Body = "Rolling back"
+		incident.Components[0].Status = statuspage.StatusPartialOutage
+		return nil
+	})
+	if err != nil {
+		t.Errorf("IncidentService.ModifyIncident returned error: %v", err)
+	}
+}
+
+func TestIncidentService_ModifyIncident_componentIDs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"i1","updated_at":"2006-01-02T15:04:05Z","components":[{"id":"a"},{"id":"b"}]}`)
+		case "PATCH":
+			body, _ := io.ReadAll(r.Body)
+			want := `{"incident":{"component_ids":["a","b","c"]}}` + "\n"
+			if string(body) != want {
+				t.Errorf("Request body = %s, want %s", body, want)
+			}
+			fmt.Fprint(w, `{"id":"i1"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	_, err := client.Incident.ModifyIncident(context.Background(), "1", "i1", func(incident *statuspage.Incident) error {
+		incident.ComponentIDs = append(incident.ComponentIDs, "c")
+		return nil
+	})
+	if err != nil {
+		t.Errorf("IncidentService.ModifyIncident returned error: %v", err)
+	}
+}
+
+func TestIncidentService_ModifyIncident_concurrent(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	reads := 0
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		reads++
+		fmt.Fprintf(w, `{"id":"i1","status":"investigating","updated_at":%d}`, 1136214245+reads)
+	})
+
+	calls := 0
+	_, err := client.Incident.ModifyIncident(context.Background(), "1", "i1", func(incident *statuspage.Incident) error {
+		calls++
+		incident.Status = statuspage.StatusIdentified
+		return nil
+	})
+
+	if _, ok := err.(*statuspage.ConcurrentModificationError); !ok {
+		t.Fatalf("IncidentService.ModifyIncident returned %v, want *ConcurrentModificationError", err)
+	}
+	if calls != 3 {
+		t.Errorf("IncidentService.ModifyIncident ran mutate %d times, want 3", calls)
+	}
+}
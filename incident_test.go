@@ -114,16 +114,20 @@ func TestCreateIncident(t *testing.T) {
 		name := "Test Incident"
 		body := "There is something going on.  We'll figure it out eventually."
 
-		incident := statuspage.Incident{
-			PageID:               page,
-			Name:                 name,
-			Body:                 body,
-			ComponentIDs:         components,
-			Status:               status,
-			DeliverNotifications: false,
+		b := statuspage.NewIncidentBuilder(name).
+			Body(body).
+			Status(status).
+			DeliverNotifications(false)
+		for _, id := range components {
+			b.Component(id, statuspage.StatusDegraded)
 		}
 
-		result, err := client.Incident.CreateIncident(context.TODO(), page, statuspage.StatusDegraded, incident)
+		incident, err := b.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := client.Incident.CreateIncident(context.TODO(), page, incident)
 		if err != nil {
 			t.Error(err)
 		}
@@ -148,9 +152,9 @@ func TestJSONEncodeIncident(t *testing.T) {
 		ComponentIDs:         components,
 		Status:               status,
 		DeliverNotifications: false,
-		Metadata: map[string]statuspage.IncidentMetadata{
+		Metadata: statuspage.IncidentMetadata{
 			"jira": {
-				Value: "value",
+				"value": "value",
 			},
 		},
 	}
@@ -0,0 +1,245 @@
+package statuspage
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Instrumentation observes every request the client sends, for metrics or
+// tracing. StartRequest is called before a request is sent and the function
+// it returns once the response has been read. The returned context is the
+// one the request is sent with, so tracers can carry their span in it.
+type Instrumentation interface {
+	StartRequest(ctx context.Context, info *RequestInfo) (context.Context, func(*ResponseInfo))
+}
+
+// OperationInstrumentation is implemented by Instrumentation that also
+// observes whole client operations. An operation is one call of a client
+// method, which may send any number of requests or call other operations.
+// StartOperation is called when the method starts and the function it
+// returns with the method's error. Requests and nested operations are
+// started with the returned context.
+type OperationInstrumentation interface {
+	StartOperation(ctx context.Context, info *OperationInfo) (context.Context, func(error))
+}
+
+// OperationInfo describes a call of a client method
+type OperationInfo struct {
+	// Service and Operation name the client method, e.g. "IncidentService"
+	// and "ModifyIncident".
+	Service   string
+	Operation string
+
+	// PageID is the page the operation is for, if any.
+	PageID string
+
+	// ComponentIDs lists the components named in the method's parameters.
+	ComponentIDs []string
+}
+
+// RequestInfo describes a request to the Statuspage API
+type RequestInfo struct {
+	// Service and Operation name the client method that sent the request,
+	// e.g. "IncidentService" and "CreateIncident".
+	Service   string
+	Operation string
+
+	// Method is the HTTP method.
+	Method string
+
+	// PageID is the page the request is for, if any. Resource is the type of
+	// resource requested, such as "incidents" or "components", and
+	// ResourceID the id of a single resource, if one was requested.
+	PageID     string
+	Resource   string
+	ResourceID string
+
+	// ComponentIDs lists the components named in the parameters of the
+	// client method.
+	ComponentIDs []string
+
+	// Attempt counts how many times this request has been made, starting
+	// at 1. See ContextWithAttempt.
+	Attempt int
+
+	// Header holds the request headers, so that tracers can add trace
+	// context to them.
+	Header http.Header
+}
+
+// ResponseInfo describes the outcome of a request to the Statuspage API
+type ResponseInfo struct {
+	// StatusCode is 0 if no response was received.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+
+	// RateLimitRemaining is the number of requests left in the current rate
+	// limit window, or -1 if the response did not say.
+	RateLimitRemaining int
+}
+
+type attemptKey struct{}
+
+// ContextWithAttempt marks requests sent with ctx as being attempt n of the
+// same call, so Instrumentation can tell retries apart
+func ContextWithAttempt(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, n)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok && n > 0 {
+		return n
+	}
+	return 1
+}
+
+// newRequestInfo describes req, naming the operation it was sent for
+func newRequestInfo(ctx context.Context, req *http.Request) *RequestInfo {
+	info := &RequestInfo{
+		Method:  req.Method,
+		Attempt: attemptFromContext(ctx),
+		Header:  req.Header,
+	}
+	if op := operationFromContext(ctx); op != nil {
+		info.Service, info.Operation = op.Service, op.Operation
+		info.ComponentIDs = op.ComponentIDs
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "pages" && i+1 < len(segments) {
+			info.PageID = segments[i+1]
+			segments = segments[i+2:]
+			break
+		}
+	}
+	if len(segments) > 0 && segments[0] == "v1" {
+		segments = segments[1:]
+	}
+	if len(segments) > 0 {
+		info.Resource = segments[0]
+	}
+	if len(segments) > 1 {
+		info.ResourceID = segments[1]
+	}
+
+	return info
+}
+
+// MultiInstrumentation returns an Instrumentation that passes every request
+// to each of instrumentations in turn, e.g. to both export metrics and trace
+func MultiInstrumentation(instrumentations ...Instrumentation) Instrumentation {
+	return multiInstrumentation(instrumentations)
+}
+
+type multiInstrumentation []Instrumentation
+
+func (m multiInstrumentation) StartRequest(ctx context.Context, info *RequestInfo) (context.Context, func(*ResponseInfo)) {
+	finishes := make([]func(*ResponseInfo), len(m))
+	for i, instrumentation := range m {
+		ctx, finishes[i] = instrumentation.StartRequest(ctx, info)
+	}
+
+	return ctx, func(resp *ResponseInfo) {
+		for i := len(finishes) - 1; i >= 0; i-- {
+			finishes[i](resp)
+		}
+	}
+}
+
+func (m multiInstrumentation) StartOperation(ctx context.Context, info *OperationInfo) (context.Context, func(error)) {
+	finishes := make([]func(error), 0, len(m))
+	for _, instrumentation := range m {
+		if oi, ok := instrumentation.(OperationInstrumentation); ok {
+			var finish func(error)
+			ctx, finish = oi.StartOperation(ctx, info)
+			finishes = append(finishes, finish)
+		}
+	}
+
+	return ctx, func(err error) {
+		for i := len(finishes) - 1; i >= 0; i-- {
+			finishes[i](err)
+		}
+	}
+}
+
+type operationKey struct{}
+
+// startOperation marks requests sent with the returned context as made for
+// operation, named as "Service.Method", and reports it to the client's
+// Instrumentation. The returned function ends the operation with its error,
+// which it returns.
+func (c *Client) startOperation(ctx context.Context, operation, pageID string, componentIDs ...string) (context.Context, func(error) error) {
+	info := &OperationInfo{PageID: pageID, ComponentIDs: uniqueIDs(componentIDs)}
+	info.Service, info.Operation = splitOperation(operation)
+	ctx = context.WithValue(ctx, operationKey{}, info)
+
+	oi, ok := c.Instrumentation.(OperationInstrumentation)
+	if !ok {
+		return ctx, func(err error) error { return err }
+	}
+
+	ctx, finish := oi.StartOperation(ctx, info)
+	return ctx, func(err error) error {
+		finish(err)
+		return err
+	}
+}
+
+// operationFromContext returns the innermost operation ctx was started for
+func operationFromContext(ctx context.Context) *OperationInfo {
+	info, _ := ctx.Value(operationKey{}).(*OperationInfo)
+	return info
+}
+
+// uniqueIDs returns ids sorted and without duplicates, or nil if there are none
+func uniqueIDs(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	unique := append([]string(nil), ids...)
+	sort.Strings(unique)
+	n := 1
+	for _, id := range unique[1:] {
+		if id != unique[n-1] {
+			unique[n] = id
+			n++
+		}
+	}
+	return unique[:n]
+}
+
+// operationName joins a service and method name, as in
+// "IncidentService.CreateIncident"
+func operationName(service, method string) string {
+	if service == "" {
+		return ""
+	}
+	return service + "." + method
+}
+
+func splitOperation(operation string) (string, string) {
+	if i := strings.Index(operation, "."); i >= 0 {
+		return operation[:i], operation[i+1:]
+	}
+	return "", operation
+}
+
+// rateLimitRemaining reads the remaining rate limit from a response
+func rateLimitRemaining(resp *http.Response) int {
+	for _, header := range []string{"X-RateLimit-Remaining", "X-Rate-Limit-Remaining"} {
+		if v := resp.Header.Get(header); v != "" {
+			if n, err := strconv.Atoi(v); err == nil {
+				return n
+			}
+		}
+	}
+	return -1
+}
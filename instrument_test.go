@@ -0,0 +1,173 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+type recordedRequest struct {
+	request  statuspage.RequestInfo
+	response statuspage.ResponseInfo
+}
+
+type recorder struct {
+	requests []recordedRequest
+}
+
+func (r *recorder) StartRequest(ctx context.Context, info *statuspage.RequestInfo) (context.Context, func(*statuspage.ResponseInfo)) {
+	info.Header.Set("X-Test-Trace", "abc")
+	return ctx, func(resp *statuspage.ResponseInfo) {
+		r.requests = append(r.requests, recordedRequest{request: *info, response: *resp})
+	}
+}
+
+func TestClient_Instrumentation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	rec := &recorder{}
+	client.Instrumentation = rec
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Test-Trace"); got != "abc" {
+			t.Errorf("X-Test-Trace header = %q, want abc", got)
+		}
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		fmt.Fprint(w, `{"id":"i1"}`)
+	})
+
+	ctx := statuspage.ContextWithAttempt(context.Background(), 2)
+	if _, err := client.Incident.GetIncident(ctx, "1", "i1"); err != nil {
+		t.Fatalf("IncidentService.GetIncident returned error: %v", err)
+	}
+	if _, err := client.GetPageTopology(context.Background(), "1"); err == nil {
+		t.Fatal("Client.GetPageTopology expected error")
+	}
+
+	if len(rec.requests) != 2 {
+		t.Fatalf("recorded %d requests, want 2", len(rec.requests))
+	}
+
+	got := rec.requests[0]
+	if got.request.Service != "IncidentService" || got.request.Operation != "GetIncident" ||
+		got.request.Method != "GET" || got.request.PageID != "1" ||
+		got.request.Resource != "incidents" || got.request.ResourceID != "i1" || got.request.Attempt != 2 {
+		t.Errorf("recorded request = %+v", got.request)
+	}
+	if got.response.StatusCode != http.StatusOK || got.response.RateLimitRemaining != 42 || got.response.Err != nil {
+		t.Errorf("recorded response = %+v", got.response)
+	}
+
+	got = rec.requests[1]
+	if got.request.Service != "GroupService" || got.request.Operation != "GetGroups" || got.request.Resource != "component-groups" {
+		t.Errorf("recorded request = %+v", got.request)
+	}
+	if got.response.StatusCode != http.StatusNotFound || got.response.RateLimitRemaining != -1 || got.response.Err == nil {
+		t.Errorf("recorded response = %+v", got.response)
+	}
+}
+
+func TestMultiInstrumentation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	first, second := &recorder{}, &recorder{}
+	client.Instrumentation = statuspage.MultiInstrumentation(first, second)
+
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"i1"}`)
+	})
+
+	b := statuspage.NewIncidentBuilder("API errors").
+		Component("web", statuspage.StatusDegraded).
+		Component("api", statuspage.StatusMajorOutage)
+	if _, err := client.Incident.CreateIncidentWith(context.Background(), "1", b); err != nil {
+		t.Fatalf("IncidentService.CreateIncidentWith returned error: %v", err)
+	}
+
+	for _, rec := range []*recorder{first, second} {
+		if len(rec.requests) != 1 {
+			t.Fatalf("recorded %d requests, want 1", len(rec.requests))
+		}
+		got := rec.requests[0].request
+		if got.Operation != "CreateIncident" || fmt.Sprint(got.ComponentIDs) != "[api web]" {
+			t.Errorf("recorded request = %+v, want CreateIncident for [api web]", got)
+		}
+	}
+}
+
+type operationRecorder struct {
+	recorder
+	operations []string
+}
+
+type parentKey struct{}
+
+func (r *operationRecorder) StartOperation(ctx context.Context, info *statuspage.OperationInfo) (context.Context, func(error)) {
+	name := info.Operation
+	if parent, ok := ctx.Value(parentKey{}).(string); ok {
+		name = parent + "/" + name
+	}
+	return context.WithValue(ctx, parentKey{}, name), func(err error) {
+		r.operations = append(r.operations, fmt.Sprintf("%s %v %v", name, info.ComponentIDs, err != nil))
+	}
+}
+
+func TestClient_OperationInstrumentation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	rec := &operationRecorder{}
+	client.Instrumentation = statuspage.MultiInstrumentation(&recorder{}, rec)
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"i1","status":"investigating","components":[{"id":"api"}]}`)
+	})
+
+	_, err := client.Incident.ModifyIncident(context.Background(), "1", "i1", func(incident *statuspage.Incident) error {
+		incident.Status = statuspage.StatusIdentified
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IncidentService.ModifyIncident returned error: %v", err)
+	}
+
+	want := []string{
+		"ModifyIncident/GetIncident [] false",
+		"ModifyIncident/GetIncident [] false",
+		"ModifyIncident/PatchIncident [] false",
+		"ModifyIncident [] false",
+	}
+	if fmt.Sprint(rec.operations) != fmt.Sprint(want) {
+		t.Errorf("recorded operations = %q, want %q", rec.operations, want)
+	}
+}
+
+func TestClient_OperationInstrumentation_lifecycle(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	rec := &operationRecorder{}
+	client.Instrumentation = rec
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"i1","status":"monitoring","components":[{"id":"api"}]}`)
+	})
+
+	if _, err := client.Incident.Lifecycle("1").Resolve(context.Background(), "i1", "Fixed"); err != nil {
+		t.Fatalf("IncidentLifecycle.Resolve returned error: %v", err)
+	}
+
+	want := []string{
+		"Resolve/GetIncident [] false",
+		"Resolve/UpdateIncident [api] false",
+		"Resolve [] false",
+	}
+	if fmt.Sprint(rec.operations) != fmt.Sprint(want) {
+		t.Errorf("recorded operations = %q, want %q", rec.operations, want)
+	}
+}
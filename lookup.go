@@ -0,0 +1,315 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MatchMode sets how FindByName compares names
+type MatchMode int
+
+const (
+	// MatchExact matches names that are identical
+	MatchExact MatchMode = iota
+	// MatchFold matches names that are equal ignoring case
+	MatchFold
+	// MatchFuzzy matches names ignoring case, spacing and punctuation, then
+	// names containing the one searched for, then names within a few typos
+	// of it. The first of these that matches anything wins.
+	MatchFuzzy
+)
+
+// FindByNameOptions specifies the optional parameters to the FindByName methods
+type FindByNameOptions struct {
+	Mode MatchMode
+
+	// GroupID restricts a component search to the components of one group,
+	// for pages where several groups hold a component of the same name.
+	GroupID string
+}
+
+// AmbiguousNameError is returned by FindByName when several resources match
+// a name. Matches holds the ids of all of them.
+type AmbiguousNameError struct {
+	Kind    string
+	Name    string
+	Matches []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%s name %q is ambiguous: matches %s", e.Kind, e.Name, strings.Join(e.Matches, ", "))
+}
+
+// NameNotFoundError is returned by FindByName when no resource matches a name
+type NameNotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *NameNotFoundError) Error() string {
+	return fmt.Sprintf("no %s named %q", e.Kind, e.Name)
+}
+
+// nameIndex caches the components and groups of each page so that names can
+// be resolved without listing them on every lookup. A page is reloaded when
+// a name is not found in it, and dropped when a component is renamed,
+// regrouped or deleted through the client.
+type nameIndex struct {
+	mu         sync.Mutex
+	components map[string][]Component
+	groups     map[string][]Group
+}
+
+func (n *nameIndex) forget(pageID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.components, pageID)
+	delete(n.groups, pageID)
+}
+
+// FindByName returns the component of a given page id with the given name.
+// The component is returned as it was when the page was last indexed, so use
+// its ID to fetch current details such as its status.
+func (s *ComponentService) FindByName(ctx context.Context, pageID, name string, opts *FindByNameOptions) (_ *Component, err error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	ctx, end := s.client.startOperation(ctx, "ComponentService.FindByName", pageID)
+	defer func() { end(err) }()
+
+	if opts == nil {
+		opts = &FindByNameOptions{}
+	}
+
+	index := &s.client.names
+	find := func(reload bool) ([]Component, error) {
+		index.mu.Lock()
+		components, ok := index.components[pageID]
+		index.mu.Unlock()
+
+		if !ok || reload {
+			var err error
+			components, err = s.ListComponents(ctx, pageID)
+			if err != nil {
+				return nil, err
+			}
+
+			index.mu.Lock()
+			if index.components == nil {
+				index.components = make(map[string][]Component)
+			}
+			index.components[pageID] = components
+			index.mu.Unlock()
+		}
+
+		candidates := make([]Component, 0, len(components))
+		for _, c := range components {
+			if !c.Group && (opts.GroupID == "" || c.GroupID == opts.GroupID) {
+				candidates = append(candidates, c)
+			}
+		}
+
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = c.Name
+		}
+
+		matched := make([]Component, 0)
+		for _, i := range matchNames(names, name, opts.Mode) {
+			matched = append(matched, candidates[i])
+		}
+		return matched, nil
+	}
+
+	matched, err := find(false)
+	if err == nil && len(matched) == 0 {
+		matched, err = find(true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, &NameNotFoundError{Kind: "component", Name: name}
+	case 1:
+		return &matched[0], nil
+	}
+
+	ids := make([]string, len(matched))
+	for i, c := range matched {
+		ids[i] = c.ID
+	}
+	return nil, &AmbiguousNameError{Kind: "component", Name: name, Matches: ids}
+}
+
+// FindByName returns the component group of a given page id with the given
+// name. The GroupID option does not apply to groups.
+func (s *GroupService) FindByName(ctx context.Context, pageID, name string, opts *FindByNameOptions) (_ *Group, err error) {
+	if pageID == "" {
+		pageID = s.client.defaultPage
+	}
+
+	ctx, end := s.client.startOperation(ctx, "GroupService.FindByName", pageID)
+	defer func() { end(err) }()
+
+	if opts == nil {
+		opts = &FindByNameOptions{}
+	}
+
+	index := &s.client.names
+	find := func(reload bool) ([]Group, error) {
+		index.mu.Lock()
+		groups, ok := index.groups[pageID]
+		index.mu.Unlock()
+
+		if !ok || reload {
+			var err error
+			groups, err = s.GetGroups(ctx, pageID)
+			if err != nil {
+				return nil, err
+			}
+
+			index.mu.Lock()
+			if index.groups == nil {
+				index.groups = make(map[string][]Group)
+			}
+			index.groups[pageID] = groups
+			index.mu.Unlock()
+		}
+
+		names := make([]string, len(groups))
+		for i, g := range groups {
+			names[i] = g.Name
+		}
+
+		matched := make([]Group, 0)
+		for _, i := range matchNames(names, name, opts.Mode) {
+			matched = append(matched, groups[i])
+		}
+		return matched, nil
+	}
+
+	matched, err := find(false)
+	if err == nil && len(matched) == 0 {
+		matched, err = find(true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, &NameNotFoundError{Kind: "group", Name: name}
+	case 1:
+		return &matched[0], nil
+	}
+
+	ids := make([]string, len(matched))
+	for i, g := range matched {
+		ids[i] = g.ID
+	}
+	return nil, &AmbiguousNameError{Kind: "group", Name: name, Matches: ids}
+}
+
+// matchNames returns the indexes of the names that match name under mode
+func matchNames(names []string, name string, mode MatchMode) []int {
+	match := func(ok func(n string) bool) []int {
+		var matched []int
+		for i, n := range names {
+			if ok(n) {
+				matched = append(matched, i)
+			}
+		}
+		return matched
+	}
+
+	switch mode {
+	case MatchExact:
+		return match(func(n string) bool { return n == name })
+	case MatchFold:
+		return match(func(n string) bool { return strings.EqualFold(n, name) })
+	}
+
+	want := normalizeName(name)
+	if want == "" {
+		return nil
+	}
+
+	if m := match(func(n string) bool { return normalizeName(n) == want }); len(m) > 0 {
+		return m
+	}
+	if m := match(func(n string) bool { return strings.Contains(normalizeName(n), want) }); len(m) > 0 {
+		return m
+	}
+
+	// Allow roughly one typo for every four characters, keeping only the
+	// closest names.
+	var closest []int
+	best := len(want)/4 + 1
+	for i, n := range names {
+		d := editDistance(normalizeName(n), want)
+		switch {
+		case d < best:
+			best = d
+			closest = []int{i}
+		case d == best && len(closest) > 0:
+			closest = append(closest, i)
+		}
+	}
+	return closest
+}
+
+// normalizeName lowercases a name and drops everything but letters and digits
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// editDistance returns the number of single rune insertions, deletions,
+// substitutions and swaps of neighbouring runes that turn a into b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min3(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && rows[i-2][j-2]+1 < rows[i][j] {
+				rows[i][j] = rows[i-2][j-2] + 1
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
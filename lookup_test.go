@@ -0,0 +1,128 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+const lookupComponents = `[
+	{"id":"g1","name":"EU","group":true},
+	{"id":"api-eu","name":"API","group_id":"g1"},
+	{"id":"api-us","name":"API","group_id":"g2"},
+	{"id":"dash","name":"Customer Dashboard"},
+	{"id":"web","name":"Website"}
+]`
+
+func TestComponentService_FindByName(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	lists := 0
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		lists++
+		fmt.Fprint(w, lookupComponents)
+	})
+
+	tests := []struct {
+		name string
+		opts *statuspage.FindByNameOptions
+		want string
+	}{
+		{"Website", nil, "web"},
+		{"website", &statuspage.FindByNameOptions{Mode: statuspage.MatchFold}, "web"},
+		{"customer-dashboard", &statuspage.FindByNameOptions{Mode: statuspage.MatchFuzzy}, "dash"},
+		{"dashboard", &statuspage.FindByNameOptions{Mode: statuspage.MatchFuzzy}, "dash"},
+		{"Webiste", &statuspage.FindByNameOptions{Mode: statuspage.MatchFuzzy}, "web"},
+		{"API", &statuspage.FindByNameOptions{GroupID: "g2"}, "api-us"},
+	}
+
+	for _, tt := range tests {
+		component, err := client.Component.FindByName(context.Background(), "1", tt.name, tt.opts)
+		if err != nil {
+			t.Errorf("ComponentService.FindByName(%q) returned error: %v", tt.name, err)
+			continue
+		}
+		if component.ID != tt.want {
+			t.Errorf("ComponentService.FindByName(%q) = %q, want %q", tt.name, component.ID, tt.want)
+		}
+	}
+
+	if lists != 1 {
+		t.Errorf("ComponentService.FindByName listed components %d times, want 1", lists)
+	}
+}
+
+func TestComponentService_FindByName_ambiguous(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, lookupComponents)
+	})
+
+	_, err := client.Component.FindByName(context.Background(), "1", "API", nil)
+
+	aerr, ok := err.(*statuspage.AmbiguousNameError)
+	if !ok {
+		t.Fatalf("ComponentService.FindByName returned %v, want *AmbiguousNameError", err)
+	}
+
+	want := &statuspage.AmbiguousNameError{Kind: "component", Name: "API", Matches: []string{"api-eu", "api-us"}}
+	if !reflect.DeepEqual(aerr, want) {
+		t.Errorf("ComponentService.FindByName error = %+v, want %+v", aerr, want)
+	}
+}
+
+func TestComponentService_FindByName_reloadsOnMiss(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	lists := 0
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		if lists == 1 {
+			fmt.Fprint(w, `[{"id":"web","name":"Website"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":"web","name":"Website"},{"id":"cdn","name":"CDN"}]`)
+	})
+
+	if _, err := client.Component.FindByName(context.Background(), "1", "Website", nil); err != nil {
+		t.Fatalf("ComponentService.FindByName returned error: %v", err)
+	}
+
+	component, err := client.Component.FindByName(context.Background(), "1", "CDN", nil)
+	if err != nil || component.ID != "cdn" {
+		t.Errorf("ComponentService.FindByName returned %+v, %v, want cdn", component, err)
+	}
+
+	_, err = client.Component.FindByName(context.Background(), "1", "Mail", nil)
+	if _, ok := err.(*statuspage.NameNotFoundError); !ok {
+		t.Errorf("ComponentService.FindByName returned %v, want *NameNotFoundError", err)
+	}
+}
+
+func TestGroupService_FindByName(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/component-groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"g1","name":"Europe"},{"id":"g2","name":"North America"}]`)
+	})
+
+	group, err := client.Group.FindByName(context.Background(), "1", "north america", &statuspage.FindByNameOptions{Mode: statuspage.MatchFold})
+	if err != nil {
+		t.Fatalf("GroupService.FindByName returned error: %v", err)
+	}
+
+	if group.ID != "g2" {
+		t.Errorf("GroupService.FindByName returned %q, want g2", group.ID)
+	}
+}
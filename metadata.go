@@ -0,0 +1,177 @@
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MetadataNamespace is the incident metadata namespace this package keeps
+// its own keys in, such as DedupeMetadataKey and OutboxMetadataKey
+const MetadataNamespace = "statuspage_go"
+
+// IncidentMetadata is the metadata attached to an incident. Statuspage groups
+// metadata into namespaces, each holding its own key/value pairs, e.g.
+// {"jira": {"issue_key": "OPS-1"}, "pagerduty": {"fingerprint": "abc"}}.
+// Values may be any JSON value, including nested objects. Numbers are kept
+// as json.Number so they round-trip without losing precision.
+type IncidentMetadata map[string]map[string]interface{}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (m *IncidentMetadata) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw map[string]map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	*m = raw
+	return nil
+}
+
+// Get returns the value stored under a namespace and key
+func (m IncidentMetadata) Get(namespace, key string) (interface{}, bool) {
+	v, ok := m[namespace][key]
+	return v, ok
+}
+
+// GetString returns the value stored under a namespace and key if it is a string
+func (m IncidentMetadata) GetString(namespace, key string) (string, bool) {
+	v, ok := m.Get(namespace, key)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// GetInt returns the value stored under a namespace and key if it is an integer
+func (m IncidentMetadata) GetInt(namespace, key string) (int64, bool) {
+	v, ok := m.Get(namespace, key)
+	if !ok {
+		return 0, false
+	}
+
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), float64(int64(n)) == n
+	}
+	return 0, false
+}
+
+// GetBool returns the value stored under a namespace and key if it is a boolean
+func (m IncidentMetadata) GetBool(namespace, key string) (bool, bool) {
+	v, ok := m.Get(namespace, key)
+	if !ok {
+		return false, false
+	}
+	b, ok := v.(bool)
+	return b, ok
+}
+
+// Set stores a value under a namespace and key, creating both as needed
+func (m *IncidentMetadata) Set(namespace, key string, value interface{}) {
+	if *m == nil {
+		*m = make(IncidentMetadata)
+	}
+	if (*m)[namespace] == nil {
+		(*m)[namespace] = make(map[string]interface{})
+	}
+	(*m)[namespace][key] = value
+}
+
+// Delete removes the value stored under a namespace and key, and the
+// namespace itself once it is empty
+func (m IncidentMetadata) Delete(namespace, key string) {
+	delete(m[namespace], key)
+	if len(m[namespace]) == 0 {
+		delete(m, namespace)
+	}
+}
+
+// Matches reports whether the value stored under a namespace and key equals
+// value. Values are compared by their string form, so the number 42 matches
+// both json.Number("42") and "42".
+func (m IncidentMetadata) Matches(namespace, key string, value interface{}) bool {
+	v, ok := m.Get(namespace, key)
+	return ok && fmt.Sprint(v) == fmt.Sprint(value)
+}
+
+// Copy returns a copy of m with its own namespaces. Nested values are shared.
+func (m IncidentMetadata) Copy() IncidentMetadata {
+	if m == nil {
+		return nil
+	}
+
+	c := make(IncidentMetadata, len(m))
+	for namespace, values := range m {
+		c[namespace] = make(map[string]interface{}, len(values))
+		for key, value := range values {
+			c[namespace][key] = value
+		}
+	}
+	return c
+}
+
+// FindIncidentsByMetadata returns the incidents of a given page id whose
+// metadata holds value under namespace and key, such as an alert fingerprint
+// or a Jira issue key. All incidents are searched, one page of results at
+// a time.
+func (s *IncidentService) FindIncidentsByMetadata(ctx context.Context, pageID, namespace, key string, value interface{}) (_ []Incident, err error) {
+	ctx, end := s.client.startOperation(ctx, "IncidentService.FindIncidentsByMetadata", pageID)
+	defer func() { end(err) }()
+
+	opts := &ListIncidentsOptions{ListOptions: ListOptions{Page: 1, PerPage: 100}}
+	found := make([]Incident, 0)
+
+	for {
+		incidents, err := s.ListIncidents(ctx, pageID, opts)
+		if err != nil {
+			return found, err
+		}
+
+		found = append(found, filterByMetadata(incidents, namespace, key, value)...)
+		if len(incidents) < opts.PerPage {
+			return found, nil
+		}
+		opts.Page++
+	}
+}
+
+// FindUnresolvedIncidentsByMetadata returns the unresolved incidents of a
+// given page id whose metadata holds value under namespace and key
+func (s *IncidentService) FindUnresolvedIncidentsByMetadata(ctx context.Context, pageID, namespace, key string, value interface{}) (_ []Incident, err error) {
+	ctx, end := s.client.startOperation(ctx, "IncidentService.FindUnresolvedIncidentsByMetadata", pageID)
+	defer func() { end(err) }()
+
+	incidents, err := s.listAllUnresolvedIncidents(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterByMetadata(incidents, namespace, key, value), nil
+}
+
+func filterByMetadata(incidents []Incident, namespace, key string, value interface{}) []Incident {
+	matched := make([]Incident, 0)
+	for _, incident := range incidents {
+		if incident.Metadata.Matches(namespace, key, value) {
+			matched = append(matched, incident)
+		}
+	}
+	return matched
+}
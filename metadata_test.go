@@ -0,0 +1,130 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestIncidentMetadata_roundTrip(t *testing.T) {
+	data := `{"alerts":{"fingerprint":"abc","paged":true,"source":{"name":"prometheus","rules":["a","b"]}},"jira":{"issue_id":12345678901234567,"issue_key":"OPS-1"}}`
+
+	var metadata statuspage.IncidentMetadata
+	if err := json.Unmarshal([]byte(data), &metadata); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if v, ok := metadata.GetString("jira", "issue_key"); !ok || v != "OPS-1" {
+		t.Errorf("IncidentMetadata.GetString = %q, %v, want OPS-1", v, ok)
+	}
+	if v, ok := metadata.GetInt("jira", "issue_id"); !ok || v != 12345678901234567 {
+		t.Errorf("IncidentMetadata.GetInt = %d, %v, want 12345678901234567", v, ok)
+	}
+	if v, ok := metadata.GetBool("alerts", "paged"); !ok || !v {
+		t.Errorf("IncidentMetadata.GetBool = %v, %v, want true", v, ok)
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if string(encoded) != data {
+		t.Errorf("json.Marshal returned %s, want %s", encoded, data)
+	}
+}
+
+func TestIncidentMetadata_SetDelete(t *testing.T) {
+	var metadata statuspage.IncidentMetadata
+	metadata.Set("jira", "issue_key", "OPS-2")
+	metadata.Set("jira", "priority", 2)
+
+	if !metadata.Matches("jira", "priority", "2") {
+		t.Error("IncidentMetadata.Matches(priority, \"2\") = false, want true")
+	}
+
+	metadata.Delete("jira", "issue_key")
+	metadata.Delete("jira", "priority")
+	if len(metadata) != 0 {
+		t.Errorf("IncidentMetadata after deletes = %v, want empty", metadata)
+	}
+}
+
+func TestIncidentService_FindIncidentsByMetadata(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"id":"i1","metadata":{"jira":{"issue_key":"OPS-1"}}},
+			{"id":"i2","metadata":{"jira":{"issue_key":"OPS-2"}}},
+			{"id":"i3"}
+		]`)
+	})
+
+	incidents, err := client.Incident.FindIncidentsByMetadata(context.Background(), "1", "jira", "issue_key", "OPS-2")
+	if err != nil {
+		t.Fatalf("IncidentService.FindIncidentsByMetadata returned error: %v", err)
+	}
+
+	if len(incidents) != 1 || incidents[0].ID != "i2" {
+		t.Errorf("IncidentService.FindIncidentsByMetadata returned %+v, want i2", incidents)
+	}
+}
+
+func TestIncidentService_FindUnresolvedIncidentsByMetadata_paginates(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":"last","metadata":{"jira":{"issue_key":"OPS-1"}}}]`)
+			return
+		}
+
+		incidents := make([]string, 100)
+		for i := range incidents {
+			incidents[i] = fmt.Sprintf(`{"id":"i%d"}`, i)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(incidents, ","))
+	})
+
+	incidents, err := client.Incident.FindUnresolvedIncidentsByMetadata(context.Background(), "1", "jira", "issue_key", "OPS-1")
+	if err != nil {
+		t.Fatalf("IncidentService.FindUnresolvedIncidentsByMetadata returned error: %v", err)
+	}
+
+	if len(incidents) != 1 || incidents[0].ID != "last" {
+		t.Errorf("IncidentService.FindUnresolvedIncidentsByMetadata returned %+v, want last", incidents)
+	}
+}
+
+func TestIncidentService_UpdateIncidentStatus_preservesMetadata(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		if !v.Incident.Metadata.Matches("jira", "issue_key", "OPS-1") {
+			t.Errorf("Request metadata = %v, want jira issue_key OPS-1", v.Incident.Metadata)
+		}
+		fmt.Fprint(w, `{"id":"i1"}`)
+	})
+
+	incident := statuspage.Incident{ID: "i1"}
+	incident.Metadata.Set("jira", "issue_key", "OPS-1")
+
+	if _, err := client.Incident.UpdateIncidentStatus(context.Background(), "1", statuspage.StatusIdentified, "Found it", incident); err != nil {
+		t.Errorf("IncidentService.UpdateIncidentStatus returned error: %v", err)
+	}
+	if _, err := client.Incident.UpdateIncidentComponentStatus(context.Background(), "1", statuspage.StatusOperational, incident); err != nil {
+		t.Errorf("IncidentService.UpdateIncidentComponentStatus returned error: %v", err)
+	}
+}
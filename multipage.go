@@ -0,0 +1,129 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// MultiPage runs the same operation across several pages at once, such as
+// one status page per region. Operations run with at most
+// Client.Concurrency pages in flight and report their results per page.
+// Pages that failed are listed in the returned PageErrors. A page that
+// failed partway, such as SetComponentStatus failing after the status was
+// changed, keeps its result alongside the error.
+type MultiPage struct {
+	client  *Client
+	PageIDs []string
+}
+
+// MultiPage returns a MultiPage for the given page ids
+func (c *Client) MultiPage(pageIDs ...string) *MultiPage {
+	return &MultiPage{client: c, PageIDs: pageIDs}
+}
+
+// AllPages returns a MultiPage for every page the token has access to
+func (c *Client) AllPages(ctx context.Context) (*MultiPage, error) {
+	pages, err := c.Page.ListPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageIDs := make([]string, 0, len(*pages))
+	for _, page := range *pages {
+		if page.ID != nil {
+			pageIDs = append(pageIDs, *page.ID)
+		}
+	}
+
+	return c.MultiPage(pageIDs...), nil
+}
+
+// Each calls fn for every page concurrently
+func (m *MultiPage) Each(ctx context.Context, fn func(ctx context.Context, pageID string) error) (err error) {
+	ctx, end := m.client.startOperation(ctx, "MultiPage.Each", "")
+	defer func() { end(err) }()
+
+	_, err = fanOut(ctx, m, false, func(ctx context.Context, pageID string) (struct{}, error) {
+		return struct{}{}, fn(ctx, pageID)
+	})
+	return err
+}
+
+// CreateIncident validates the incident in b and creates it on every page.
+// Component ids differ between pages, so an incident affecting components
+// is better created through Each with a builder per page.
+func (m *MultiPage) CreateIncident(ctx context.Context, b *IncidentBuilder) (_ map[string]*Incident, err error) {
+	ctx, end := m.client.startOperation(ctx, "MultiPage.CreateIncident", "")
+	defer func() { end(err) }()
+
+	if _, err := b.Build(); err != nil {
+		return nil, err
+	}
+
+	return fanOut(ctx, m, false, func(ctx context.Context, pageID string) (*Incident, error) {
+		return m.client.Incident.CreateIncidentWith(ctx, pageID, b)
+	})
+}
+
+// SetComponentStatus sets the status of the component with the given name
+// on every page, correlating it with each page's incidents as described by
+// Client.SetComponentStatus. A page without such a component is reported as
+// failed.
+func (m *MultiPage) SetComponentStatus(ctx context.Context, componentName, status string, opts *SetComponentStatusOptions) (_ map[string]*ComponentStatusSummary, err error) {
+	ctx, end := m.client.startOperation(ctx, "MultiPage.SetComponentStatus", "")
+	defer func() { end(err) }()
+
+	return fanOut(ctx, m, true, func(ctx context.Context, pageID string) (*ComponentStatusSummary, error) {
+		components, err := m.client.Component.ListComponents(ctx, pageID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, component := range components {
+			if !component.Group && component.Name == componentName {
+				return m.client.SetComponentStatus(ctx, pageID, component.ID, status, opts)
+			}
+		}
+		return nil, fmt.Errorf("component %q not found", componentName)
+	})
+}
+
+// Topologies returns the topology of every page
+func (m *MultiPage) Topologies(ctx context.Context) (_ map[string]*PageTopology, err error) {
+	ctx, end := m.client.startOperation(ctx, "MultiPage.Topologies", "")
+	defer func() { end(err) }()
+
+	return fanOut(ctx, m, false, m.client.GetPageTopology)
+}
+
+// fanOut calls fn for every page of m and gathers the results by page id.
+// With keepPartial, a non-zero result that fn returns together with an
+// error is kept as well.
+func fanOut[T any](ctx context.Context, m *MultiPage, keepPartial bool, fn func(ctx context.Context, pageID string) (T, error)) (map[string]T, error) {
+	results := make(map[string]T, len(m.PageIDs))
+	failed := make(PageErrors)
+	var mu sync.Mutex
+
+	m.client.parallel(len(m.PageIDs), func(i int) {
+		pageID := m.PageIDs[i]
+		result, err := fn(ctx, pageID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed[pageID] = err
+			if !keepPartial || reflect.ValueOf(&result).Elem().IsZero() {
+				return
+			}
+		}
+		results[pageID] = result
+	})
+
+	if len(failed) > 0 {
+		return results, failed
+	}
+
+	return results, nil
+}
@@ -0,0 +1,149 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestClient_AllPages(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"eu"},{"id":"us"}]`)
+	})
+
+	m, err := client.AllPages(context.Background())
+	if err != nil {
+		t.Fatalf("Client.AllPages returned error: %v", err)
+	}
+
+	if want := []string{"eu", "us"}; !reflect.DeepEqual(m.PageIDs, want) {
+		t.Errorf("Client.AllPages page ids = %v, want %v", m.PageIDs, want)
+	}
+}
+
+func TestMultiPage_CreateIncident(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, page := range []string{"eu", "us"} {
+		page := page
+		mux.HandleFunc("/v1/pages/"+page+"/incidents", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			fmt.Fprintf(w, `{"id":"%s-1","page_id":%q}`, page, page)
+		})
+	}
+	mux.HandleFunc("/v1/pages/ap/incidents", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	})
+
+	b := statuspage.NewIncidentBuilder("Login failures").Status(statuspage.StatusInvestigating)
+	incidents, err := client.MultiPage("eu", "us", "ap").CreateIncident(context.Background(), b)
+
+	perr, ok := err.(statuspage.PageErrors)
+	if !ok || len(perr) != 1 || perr["ap"] == nil {
+		t.Errorf("MultiPage.CreateIncident returned error %v, want PageErrors for ap", err)
+	}
+
+	ids := make([]string, 0, len(incidents))
+	for page, incident := range incidents {
+		if incident.PageID != page {
+			t.Errorf("MultiPage.CreateIncident returned incident of page %q for %q", incident.PageID, page)
+		}
+		ids = append(ids, incident.ID)
+	}
+	sort.Strings(ids)
+	if want := []string{"eu-1", "us-1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("MultiPage.CreateIncident created %v, want %v", ids, want)
+	}
+}
+
+func TestMultiPage_SetComponentStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/eu/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"eu-api","name":"API"}]`)
+	})
+	mux.HandleFunc("/v1/pages/eu/components/eu-api", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"eu-api","name":"API","status":"degraded_performance"}`)
+	})
+	mux.HandleFunc("/v1/pages/eu/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/v1/pages/us/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"us-web","name":"Web"}]`)
+	})
+
+	summaries, err := client.MultiPage("eu", "us").SetComponentStatus(context.Background(), "API", statuspage.StatusDegraded, nil)
+
+	perr, ok := err.(statuspage.PageErrors)
+	if !ok || perr["us"] == nil {
+		t.Errorf("MultiPage.SetComponentStatus returned error %v, want PageErrors for us", err)
+	}
+	if len(summaries) != 1 || summaries["eu"].Component.ID != "eu-api" {
+		t.Errorf("MultiPage.SetComponentStatus returned %+v, want summary for eu-api", summaries)
+	}
+}
+
+func TestMultiPage_SetComponentStatus_partial(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/ap/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"ap-api","name":"API"}]`)
+	})
+	mux.HandleFunc("/v1/pages/ap/components/ap-api", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"ap-api","name":"API","status":"major_outage"}`)
+	})
+	mux.HandleFunc("/v1/pages/ap/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"i1","components":[{"id":"ap-api"}]}]`)
+	})
+	mux.HandleFunc("/v1/pages/ap/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"boom"}`, http.StatusInternalServerError)
+	})
+
+	opts := &statuspage.SetComponentStatusOptions{Policy: statuspage.PolicyAttach}
+	summaries, err := client.MultiPage("ap").SetComponentStatus(context.Background(), "API", statuspage.StatusMajorOutage, opts)
+
+	perr, ok := err.(statuspage.PageErrors)
+	if !ok || perr["ap"] == nil {
+		t.Errorf("MultiPage.SetComponentStatus returned error %v, want PageErrors for ap", err)
+	}
+	if summaries["ap"] == nil || summaries["ap"].Component.ID != "ap-api" {
+		t.Errorf("MultiPage.SetComponentStatus returned %+v, want the summary for ap kept", summaries)
+	}
+}
+
+func TestMultiPage_Topologies(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	for _, page := range []string{"eu", "us"} {
+		mux.HandleFunc("/v1/pages/"+page+"/component-groups", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[]`)
+		})
+		mux.HandleFunc("/v1/pages/"+page+"/components", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"id":"api","name":"API"}]`)
+		})
+	}
+
+	topologies, err := client.MultiPage("eu", "us").Topologies(context.Background())
+	if err != nil {
+		t.Fatalf("MultiPage.Topologies returned error: %v", err)
+	}
+
+	for _, page := range []string{"eu", "us"} {
+		if topologies[page] == nil || topologies[page].PageID != page || len(topologies[page].Ungrouped) != 1 {
+			t.Errorf("MultiPage.Topologies[%q] = %+v", page, topologies[page])
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package statuspage
+
+import (
+	"encoding/json"
+)
+
+// Nullable is a field of an update request that tells apart three states:
+// unset, set to a value (including the zero value), and explicitly null.
+// Unset fields are left out of the request, so they must be tagged
+// omitempty; null fields are sent as JSON null, which clears them.
+//
+// Nullable is a map so that omitempty works without any special support
+// from encoding/json: an unset field is a nil map. Use Value and Null to
+// construct one rather than building the map by hand.
+type Nullable[T any] map[bool]T
+
+// Value returns a Nullable that is set to v
+func Value[T any](v T) Nullable[T] {
+	return Nullable[T]{true: v}
+}
+
+// Null returns a Nullable that is explicitly null
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{false: *new(T)}
+}
+
+// nonZero returns a Nullable set to v, or an unset one when v is the zero
+// value
+func nonZero[T comparable](v T) Nullable[T] {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return Value(v)
+}
+
+// IsSet reports whether n is set to a value or to null
+func (n Nullable[T]) IsSet() bool {
+	return len(n) != 0
+}
+
+// IsNull reports whether n is explicitly null
+func (n Nullable[T]) IsNull() bool {
+	_, null := n[false]
+	return null
+}
+
+// Get returns the value of n, and whether n is set to a value
+func (n Nullable[T]) Get() (T, bool) {
+	v, ok := n[true]
+	return v, ok
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	v, ok := n.Get()
+	if !ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Null[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*n = Value(v)
+	return nil
+}
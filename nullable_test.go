@@ -0,0 +1,55 @@
+package statuspage_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestNullable_marshal(t *testing.T) {
+	params := statuspage.UpdateComponentParams{
+		Description: statuspage.Null[string](),
+		GroupID:     statuspage.Value(""),
+		Showcase:    statuspage.Value(false),
+	}
+
+	testJSONMarshal(t, &params, `{
+		"description": null,
+		"group_id": "",
+		"showcase": false
+	}`)
+
+	testJSONMarshal(t, &statuspage.UpdateComponentParams{}, `{}`)
+}
+
+func TestNullable_unmarshal(t *testing.T) {
+	var params statuspage.UpdatePageParams
+	err := json.Unmarshal([]byte(`{"name": "a", "domain": null, "hidden_from_search": false}`), &params)
+	if err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if v, ok := params.Name.Get(); !ok || v != "a" {
+		t.Errorf("Name = %v, want set to a", params.Name)
+	}
+	if !params.Domain.IsNull() {
+		t.Errorf("Domain = %v, want null", params.Domain)
+	}
+	if v, ok := params.HiddenFromSearch.Get(); !ok || v {
+		t.Errorf("HiddenFromSearch = %v, want set to false", params.HiddenFromSearch)
+	}
+	if params.Subdomain.IsSet() {
+		t.Errorf("Subdomain = %v, want unset", params.Subdomain)
+	}
+
+	want := statuspage.UpdatePageParams{
+		Name:             statuspage.Value("a"),
+		Domain:           statuspage.Null[string](),
+		HiddenFromSearch: statuspage.Value(false),
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("json.Unmarshal returned %+v, want %+v", params, want)
+	}
+}
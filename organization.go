@@ -0,0 +1,183 @@
+package statuspage
+
+import (
+	"context"
+)
+
+// OrganizationService handles communication with the organization user and
+// permission related methods of the Statuspage API.
+//
+// Statuspage API docs: https://developer.statuspage.io/#tag/users
+type OrganizationService service
+
+// User is the Statuspage API organization user representation
+type User struct {
+	ID             string    `json:"id,omitempty"`
+	OrganizationID string    `json:"organization_id,omitempty"`
+	Email          string    `json:"email,omitempty"`
+	FirstName      string    `json:"first_name,omitempty"`
+	LastName       string    `json:"last_name,omitempty"`
+	CreatedAt      Timestamp `json:"created_at,omitempty"`
+	UpdatedAt      Timestamp `json:"updated_at,omitempty"`
+}
+
+func (u User) String() string {
+	return Stringify(u)
+}
+
+// CreateUserParams are the parameters used to invite a user to an organization
+type CreateUserParams struct {
+	Email     string `json:"email"`
+	Password  string `json:"password,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+}
+
+// CreateUserRequestBody is the create user request body representation
+type CreateUserRequestBody struct {
+	User CreateUserParams `json:"user"`
+}
+
+// PagePermissions are the permissions a user holds on a single page
+type PagePermissions struct {
+	PageID             string `json:"page_id,omitempty"`
+	PageConfiguration  bool   `json:"page_configuration"`
+	IncidentManager    bool   `json:"incident_manager"`
+	MaintenanceManager bool   `json:"maintenance_manager"`
+}
+
+// Roles are common sets of page permissions
+var (
+	RolePageAdmin          = PagePermissions{PageConfiguration: true, IncidentManager: true, MaintenanceManager: true}
+	RoleIncidentManager    = PagePermissions{IncidentManager: true, MaintenanceManager: true}
+	RoleMaintenanceManager = PagePermissions{MaintenanceManager: true}
+)
+
+// UserPermissions are the permissions a user holds across an organization's pages
+type UserPermissions struct {
+	UserID string            `json:"user_id,omitempty"`
+	Pages  []PagePermissions `json:"pages"`
+}
+
+// UserPermissionsResponse is the user permissions response body representation
+type UserPermissionsResponse struct {
+	Data UserPermissions `json:"data"`
+}
+
+// UpdateUserPermissionsRequestBody is the update user permissions request body
+// representation, keyed by page id
+type UpdateUserPermissionsRequestBody struct {
+	Pages map[string]PagePermissions `json:"pages"`
+}
+
+// ListUsers returns a list of users for a given organization id
+func (s *OrganizationService) ListUsers(ctx context.Context, organizationID string, opts *ListOptions) ([]User, error) {
+	path := addOptions("v1/organizations/"+organizationID+"/users", opts)
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "OrganizationService.ListUsers", "")
+	var users []User
+	_, err = s.client.do(ctx, req, &users)
+
+	return users, end(err)
+}
+
+// ListAllUsers returns every user of a given organization id, following
+// pagination until a short page is returned
+func (s *OrganizationService) ListAllUsers(ctx context.Context, organizationID string) (_ []User, err error) {
+	ctx, end := s.client.startOperation(ctx, "OrganizationService.ListAllUsers", "")
+	defer func() { end(err) }()
+
+	opts := &ListOptions{Page: 1, PerPage: 100}
+	all := make([]User, 0)
+
+	for {
+		users, err := s.ListUsers(ctx, organizationID, opts)
+		if err != nil {
+			return all, err
+		}
+
+		all = append(all, users...)
+		if len(users) < opts.PerPage {
+			return all, nil
+		}
+		opts.Page++
+	}
+}
+
+// InviteUser creates a user in a given organization and, when pages is not
+// empty, grants it the given permissions per page id
+func (s *OrganizationService) InviteUser(ctx context.Context, organizationID string, user CreateUserParams, pages map[string]PagePermissions) (*User, error) {
+	path := "v1/organizations/" + organizationID + "/users"
+	payload := CreateUserRequestBody{User: user}
+	req, err := s.client.newRequest("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "OrganizationService.InviteUser", "")
+	var createdUser User
+	_, err = s.client.do(ctx, req, &createdUser)
+	if err != nil || len(pages) == 0 {
+		return &createdUser, end(err)
+	}
+
+	_, err = s.UpdateUserPermissions(ctx, organizationID, createdUser.ID, pages)
+
+	return &createdUser, end(err)
+}
+
+// DeleteUser removes a user from a given organization
+func (s *OrganizationService) DeleteUser(ctx context.Context, organizationID string, userID string) error {
+	path := "v1/organizations/" + organizationID + "/users/" + userID
+	req, err := s.client.newRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "OrganizationService.DeleteUser", "")
+	_, err = s.client.do(ctx, req, nil)
+	return end(err)
+}
+
+// GetUserPermissions returns the page permissions of a user in a given organization
+func (s *OrganizationService) GetUserPermissions(ctx context.Context, organizationID string, userID string) (*UserPermissions, error) {
+	path := "v1/organizations/" + organizationID + "/permissions/" + userID
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "OrganizationService.GetUserPermissions", "")
+	var permissions UserPermissionsResponse
+	_, err = s.client.do(ctx, req, &permissions)
+
+	return &permissions.Data, end(err)
+}
+
+// UpdateUserPermissions replaces the page permissions of a user in a given
+// organization. Pages left out of the map lose all permissions.
+func (s *OrganizationService) UpdateUserPermissions(ctx context.Context, organizationID string, userID string, pages map[string]PagePermissions) (*UserPermissions, error) {
+	path := "v1/organizations/" + organizationID + "/permissions/" + userID
+
+	body := make(map[string]PagePermissions, len(pages))
+	for pageID, p := range pages {
+		p.PageID = ""
+		body[pageID] = p
+	}
+
+	payload := UpdateUserPermissionsRequestBody{Pages: body}
+	req, err := s.client.newRequest("PUT", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "OrganizationService.UpdateUserPermissions", "")
+	var permissions UserPermissionsResponse
+	_, err = s.client.do(ctx, req, &permissions)
+
+	return &permissions.Data, end(err)
+}
@@ -0,0 +1,121 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestOrganizationService_ListAllUsers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/organizations/o/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("page") == "1" {
+			users := make([]string, 100)
+			for i := range users {
+				users[i] = fmt.Sprintf(`{"id":"u%d"}`, i)
+			}
+			fmt.Fprint(w, "["+strings.Join(users, ",")+"]")
+			return
+		}
+		fmt.Fprint(w, `[{"id":"last"}]`)
+	})
+
+	users, err := client.Organization.ListAllUsers(context.Background(), "o")
+	if err != nil {
+		t.Errorf("OrganizationService.ListAllUsers returned error: %v", err)
+	}
+
+	if len(users) != 101 || users[100].ID != "last" {
+		t.Errorf("OrganizationService.ListAllUsers returned %d users, want 101", len(users))
+	}
+}
+
+func TestOrganizationService_InviteUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := statuspage.CreateUserParams{Email: "a@example.com", FirstName: "A"}
+
+	mux.HandleFunc("/v1/organizations/o/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		v := &statuspage.CreateUserRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.User, input) {
+			t.Errorf("Request body = %+v, want %+v", v.User, input)
+		}
+
+		fmt.Fprint(w, `{"id":"u1","email":"a@example.com"}`)
+	})
+
+	mux.HandleFunc("/v1/organizations/o/permissions/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		v := &statuspage.UpdateUserPermissionsRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		want := map[string]statuspage.PagePermissions{"p1": statuspage.RoleIncidentManager}
+		if !reflect.DeepEqual(v.Pages, want) {
+			t.Errorf("Request pages = %+v, want %+v", v.Pages, want)
+		}
+
+		fmt.Fprint(w, `{"data":{"user_id":"u1","pages":[{"page_id":"p1","incident_manager":true,"maintenance_manager":true}]}}`)
+	})
+
+	pages := map[string]statuspage.PagePermissions{"p1": statuspage.RoleIncidentManager}
+	user, err := client.Organization.InviteUser(context.Background(), "o", input, pages)
+	if err != nil {
+		t.Errorf("OrganizationService.InviteUser returned error: %v", err)
+	}
+
+	want := &statuspage.User{ID: "u1", Email: "a@example.com"}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("OrganizationService.InviteUser returned %+v, want %+v", user, want)
+	}
+}
+
+func TestOrganizationService_GetUserPermissions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/organizations/o/permissions/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"user_id":"u1","pages":[{"page_id":"p1","page_configuration":true}]}}`)
+	})
+
+	permissions, err := client.Organization.GetUserPermissions(context.Background(), "o", "u1")
+	if err != nil {
+		t.Errorf("OrganizationService.GetUserPermissions returned error: %v", err)
+	}
+
+	want := &statuspage.UserPermissions{
+		UserID: "u1",
+		Pages:  []statuspage.PagePermissions{{PageID: "p1", PageConfiguration: true}},
+	}
+	if !reflect.DeepEqual(permissions, want) {
+		t.Errorf("OrganizationService.GetUserPermissions returned %+v, want %+v", permissions, want)
+	}
+}
+
+func TestOrganizationService_DeleteUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/organizations/o/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{}`)
+	})
+
+	err := client.Organization.DeleteUser(context.Background(), "o", "u1")
+	if err != nil {
+		t.Errorf("OrganizationService.DeleteUser returned error: %v", err)
+	}
+}
@@ -0,0 +1,442 @@
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// OutboxMetadataNamespace and OutboxMetadataKey are where the outbox
+	// records its entry id in the metadata of incidents it creates, so that
+	// a replay can tell whether the original request went through after all.
+	OutboxMetadataNamespace = MetadataNamespace
+	OutboxMetadataKey       = "outbox_id"
+)
+
+// DefaultOutboxOperations are the client methods whose failed requests an
+// Outbox keeps unless told otherwise
+var DefaultOutboxOperations = []string{
+	"IncidentService.CreateIncident",
+	"IncidentService.UpdateIncident",
+	"IncidentService.UpdateIncidentStatus",
+	"ComponentService.UpdateComponent",
+}
+
+// OutboxEntry is a failed request waiting in an Outbox
+type OutboxEntry struct {
+	ID          string    `json:"id"`
+	Operation   string    `json:"operation"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	QueuedAt    time.Time `json:"queued_at"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+
+	// Tagged is set when the request carries the entry id in incident
+	// metadata under OutboxMetadataNamespace and OutboxMetadataKey.
+	Tagged bool `json:"tagged,omitempty"`
+
+	// Failed is set when a replay failed in a way sending it again will not
+	// fix, such as the API answering 404. Failed entries are not replayed and
+	// do not hold back other requests, but stay in the outbox until dropped.
+	Failed bool `json:"failed,omitempty"`
+}
+
+// PageID returns the page the entry's request is for
+func (e OutboxEntry) PageID() string {
+	return pageIDFromPath(e.Path)
+}
+
+// OutboxPendingError is returned by a client method whose request was not
+// sent because earlier requests of the client's Outbox are still waiting to
+// be replayed. Sending it would let it overtake them.
+type OutboxPendingError struct {
+	Pending int
+}
+
+func (e *OutboxPendingError) Error() string {
+	return fmt.Sprintf("not sent while %d earlier outbox entries are pending", e.Pending)
+}
+
+// QueuedError is returned by a client method whose request failed and was
+// kept in the client's Outbox. Err is the original failure.
+type QueuedError struct {
+	EntryID string
+	Err     error
+}
+
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("%s (queued in outbox as %s)", e.Err, e.EntryID)
+}
+
+func (e *QueuedError) Unwrap() error {
+	return e.Err
+}
+
+// Outbox is a durable journal of failed mutating requests. When set as
+// Client.Outbox, requests of the listed operations that fail because the API
+// could not be reached, answered 429 or answered with a server error are
+// written to a local file, and the call returns a *QueuedError. Replay sends
+// them again, oldest first.
+//
+// While any entry is waiting to be replayed, requests of the listed
+// operations are queued behind it without being sent, so that a replay never overwrites a newer
+// change. Requests that must not be queued, such as those of
+// BulkUpdateStatus with rollback, fail with an *OutboxPendingError instead.
+//
+// Incidents created through a tracked operation are tagged with a unique id
+// in their metadata, so a replay skips incidents that were created even
+// though the original request appeared to fail.
+type Outbox struct {
+	path string
+
+	// Operations lists the client methods whose requests are kept, as
+	// "Service.Method". Defaults to DefaultOutboxOperations.
+	Operations []string
+
+	mu      sync.Mutex
+	entries []OutboxEntry
+}
+
+// OpenOutbox opens the outbox journal at path, loading any entries left by
+// earlier runs. The file is created when the first entry is queued.
+func OpenOutbox(path string) (*Outbox, error) {
+	o := &Outbox{path: path, Operations: DefaultOutboxOperations}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var entry OutboxEntry
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("failed to read outbox journal %s: %s", path, err)
+		}
+		o.entries = append(o.entries, entry)
+	}
+
+	return o, nil
+}
+
+// Pending returns the entries waiting to be replayed, oldest first, along
+// with those set aside as failed
+func (o *Outbox) Pending() []OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]OutboxEntry(nil), o.entries...)
+}
+
+// Drop removes an entry without replaying it
+func (o *Outbox) Drop(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i, entry := range o.entries {
+		if entry.ID == id {
+			o.entries = append(o.entries[:i:i], o.entries[i+1:]...)
+			return o.persistLocked()
+		}
+	}
+	return fmt.Errorf("no outbox entry %s", id)
+}
+
+// Replay sends the pending entries with c, oldest first, removing each one
+// that succeeds. It stops at the first entry that fails in a way a later
+// attempt may not, so later requests never overtake earlier ones; that entry
+// stays pending with its error recorded. Entries the API refuses are marked
+// Failed and skipped. Replay returns the number of entries that were
+// completed.
+func (o *Outbox) Replay(ctx context.Context, c *Client) (int, error) {
+	replayed := 0
+	for _, entry := range o.Pending() {
+		if entry.Failed {
+			continue
+		}
+
+		if err := o.replay(ctx, c, entry); err != nil {
+			failed := ctx.Err() == nil && !shouldQueue(err)
+			o.update(entry.ID, func(e *OutboxEntry) {
+				e.Attempts++
+				e.LastError = err.Error()
+				e.Failed = failed
+			})
+			if failed {
+				continue
+			}
+			return replayed, fmt.Errorf("failed to replay outbox entry %s: %s", entry.ID, err)
+		}
+
+		if err := o.Drop(entry.ID); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func (o *Outbox) replay(ctx context.Context, c *Client, entry OutboxEntry) (err error) {
+	ctx, end := c.startOperation(ctx, entry.Operation, entry.PageID())
+	defer func() { end(err) }()
+
+	if entry.Tagged {
+		found, err := c.Incident.FindIncidentsByMetadata(ctx, entry.PageID(), OutboxMetadataNamespace, OutboxMetadataKey, entry.ID)
+		if err != nil {
+			return err
+		}
+		if len(found) > 0 {
+			return nil
+		}
+
+		// An incident from CreateIncidentOnce is not created again while
+		// another with its dedupe key is unresolved, such as one replayed
+		// from an earlier entry.
+		if key, ok := dedupeKeyFromBody(entry.Body); ok {
+			found, err := c.Incident.FindUnresolvedIncidentsByMetadata(ctx, entry.PageID(), MetadataNamespace, DedupeMetadataKey, key)
+			if err != nil {
+				return err
+			}
+			if len(found) > 0 {
+				return nil
+			}
+		}
+	}
+
+	req, err := c.newRawRequest(entry.Method, entry.Path, entry.ContentType, bytes.NewReader(entry.Body))
+	if err != nil {
+		return err
+	}
+
+	ctx = ContextWithAttempt(ctx, entry.Attempts+1)
+	ctx = context.WithValue(ctx, replayKey{}, true)
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// tracks reports whether failed requests of operation are kept
+func (o *Outbox) tracks(operation string) bool {
+	for _, op := range o.Operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// pending returns the number of entries waiting to be replayed, not
+// counting failed ones
+func (o *Outbox) pending() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	n := 0
+	for _, entry := range o.entries {
+		if !entry.Failed {
+			n++
+		}
+	}
+	return n
+}
+
+// send sends req for operation, keeping it in the outbox if it fails in a
+// way a later attempt may not. While entries are pending, req is queued
+// behind them without being sent.
+func (o *Outbox) send(ctx context.Context, c *Client, operation string, req *http.Request, v interface{}) (*http.Response, error) {
+	pending := o.pending()
+	if pending > 0 && !queueable(ctx) {
+		return nil, &OutboxPendingError{Pending: pending}
+	}
+
+	entry := OutboxEntry{
+		ID:          newOutboxID(),
+		Operation:   operation,
+		Method:      req.Method,
+		Path:        strings.TrimPrefix(req.URL.String(), c.BaseURL.String()),
+		ContentType: req.Header.Get("Content-Type"),
+		Attempts:    1,
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		entry.Body, err = io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Method == "POST" {
+		if tagged, ok := tagIncidentBody(entry.Body, entry.ID); ok {
+			entry.Body = tagged
+			entry.Tagged = true
+			req.Body = io.NopCloser(bytes.NewReader(tagged))
+			req.ContentLength = int64(len(tagged))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(tagged)), nil
+			}
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	if pending > 0 {
+		err = &OutboxPendingError{Pending: pending}
+	} else {
+		resp, err = c.observe(ctx, req, v)
+		if err == nil || !queueable(ctx) || !shouldQueue(err) {
+			return resp, err
+		}
+	}
+
+	entry.QueuedAt = time.Now().UTC()
+	entry.LastError = err.Error()
+
+	o.mu.Lock()
+	o.entries = append(o.entries, entry)
+	perr := o.persistLocked()
+	o.mu.Unlock()
+
+	if perr != nil {
+		return resp, fmt.Errorf("%s; failed to queue in outbox: %s", err, perr)
+	}
+	return resp, &QueuedError{EntryID: entry.ID, Err: err}
+}
+
+func (o *Outbox) update(id string, fn func(e *OutboxEntry)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].ID == id {
+			fn(&o.entries[i])
+			o.persistLocked()
+			return
+		}
+	}
+}
+
+// persistLocked rewrites the journal with the current entries
+func (o *Outbox) persistLocked() error {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	for _, entry := range o.entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path)
+}
+
+type replayKey struct{}
+
+func isReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey{}).(bool)
+	return replay
+}
+
+type noQueueKey struct{}
+
+// withoutQueue marks requests sent with ctx as never to be kept in an
+// Outbox, for changes that are undone when they fail
+func withoutQueue(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueueKey{}, true)
+}
+
+func queueable(ctx context.Context) bool {
+	noQueue, _ := ctx.Value(noQueueKey{}).(bool)
+	return !noQueue
+}
+
+// shouldQueue reports whether a failed request may succeed if sent again:
+// the API could not be reached in time, was rate limiting or had a server
+// error
+func shouldQueue(err error) bool {
+	var rerr *ErrorResponse
+	if errors.As(err, &rerr) {
+		code := rerr.Response.StatusCode
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	}
+
+	var nerr net.Error
+	return errors.As(err, &nerr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// tagIncidentBody adds id to the metadata of an {"incident": {...}} request body
+func tagIncidentBody(body []byte, id string) ([]byte, bool) {
+	var payload map[string]json.RawMessage
+	if json.Unmarshal(body, &payload) != nil || payload["incident"] == nil {
+		return nil, false
+	}
+
+	var incident map[string]json.RawMessage
+	if json.Unmarshal(payload["incident"], &incident) != nil {
+		return nil, false
+	}
+
+	var metadata IncidentMetadata
+	if raw, ok := incident["metadata"]; ok && json.Unmarshal(raw, &metadata) != nil {
+		return nil, false
+	}
+	metadata.Set(OutboxMetadataNamespace, OutboxMetadataKey, id)
+
+	var err error
+	if incident["metadata"], err = json.Marshal(metadata); err != nil {
+		return nil, false
+	}
+	if payload["incident"], err = json.Marshal(incident); err != nil {
+		return nil, false
+	}
+
+	tagged, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return append(tagged, '\n'), true
+}
+
+// dedupeKeyFromBody returns the dedupe key in the metadata of an
+// {"incident": {...}} request body
+func dedupeKeyFromBody(body []byte) (string, bool) {
+	var payload UpdateIncidentRequestBody
+	if json.Unmarshal(body, &payload) != nil {
+		return "", false
+	}
+	return payload.Incident.Metadata.GetString(MetadataNamespace, DedupeMetadataKey)
+}
+
+func newOutboxID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,320 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func setupOutbox(t *testing.T, client *statuspage.Client) string {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	outbox, err := statuspage.OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("OpenOutbox returned error: %v", err)
+	}
+	client.Outbox = outbox
+	return path
+}
+
+func TestOutbox_queueAndReplay(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	path := setupOutbox(t, client)
+
+	down := true
+	var sent []string
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+
+		testMethod(t, r, "POST")
+		if down {
+			http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		name, _ := v.Incident.Name.Get()
+		sent = append(sent, name)
+		fmt.Fprint(w, `{"id":"i1"}`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		if down {
+			t.Error("request sent while an earlier one is pending")
+			http.Error(w, `{"error":"unavailable"}`, http.StatusBadGateway)
+			return
+		}
+		sent = append(sent, "component")
+		fmt.Fprint(w, `{"id":"api"}`)
+	})
+
+	_, err := client.Incident.CreateIncident(context.Background(), "1", &statuspage.IncidentUpdate{Name: statuspage.Value("API down")})
+	if _, ok := err.(*statuspage.QueuedError); !ok {
+		t.Fatalf("IncidentService.CreateIncident returned %v, want *QueuedError", err)
+	}
+	_, err = client.Component.UpdateComponent(context.Background(), "1", "api", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusMajorOutage)})
+	if qerr, ok := err.(*statuspage.QueuedError); !ok {
+		t.Fatalf("ComponentService.UpdateComponent returned %v, want *QueuedError", err)
+	} else if perr, ok := qerr.Err.(*statuspage.OutboxPendingError); !ok || perr.Pending != 1 {
+		t.Errorf("QueuedError.Err = %v, want *OutboxPendingError for 1 entry", qerr.Err)
+	}
+
+	// A fresh outbox reads the same journal.
+	outbox, err := statuspage.OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("OpenOutbox returned error: %v", err)
+	}
+	pending := outbox.Pending()
+	if len(pending) != 2 || pending[0].Operation != "IncidentService.CreateIncident" || !pending[0].Tagged || pending[1].Operation != "ComponentService.UpdateComponent" {
+		t.Fatalf("Outbox.Pending returned %+v", pending)
+	}
+
+	if n, err := outbox.Replay(context.Background(), client); err == nil || n != 0 {
+		t.Errorf("Outbox.Replay while down = %d, %v, want 0 and error", n, err)
+	}
+	if pending := outbox.Pending(); len(pending) != 2 || pending[0].Attempts != 2 {
+		t.Errorf("Outbox.Pending after failed replay = %+v, want 2 entries with first at 2 attempts", pending)
+	}
+
+	down = false
+	n, err := outbox.Replay(context.Background(), client)
+	if err != nil || n != 2 {
+		t.Fatalf("Outbox.Replay = %d, %v, want 2", n, err)
+	}
+	if fmt.Sprint(sent) != "[API down component]" {
+		t.Errorf("replayed requests = %v, want incident then component", sent)
+	}
+	if pending := outbox.Pending(); len(pending) != 0 {
+		t.Errorf("Outbox.Pending after replay = %+v, want none", pending)
+	}
+}
+
+func TestOutbox_replaySkipsCreatedIncident(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	setupOutbox(t, client)
+
+	var tag string
+	created := false
+	mux.HandleFunc("/v1/pages/1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			// The incident has been resolved since.
+			fmt.Fprintf(w, `[{"id":"i1","status":"resolved","metadata":{%q:{%q:%q}}}]`, statuspage.OutboxMetadataNamespace, statuspage.OutboxMetadataKey, tag)
+			return
+		}
+		if created {
+			t.Error("incident created twice")
+		}
+		v := &statuspage.UpdateIncidentRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		tag, _ = v.Incident.Metadata.GetString(statuspage.OutboxMetadataNamespace, statuspage.OutboxMetadataKey)
+		created = true
+
+		// The incident is created, but the response never makes it back.
+		http.Error(w, `{"error":"gateway timeout"}`, http.StatusGatewayTimeout)
+	})
+	_, err := client.Incident.CreateIncident(context.Background(), "1", &statuspage.IncidentUpdate{Name: statuspage.Value("API down")})
+	qerr, ok := err.(*statuspage.QueuedError)
+	if !ok {
+		t.Fatalf("IncidentService.CreateIncident returned %v, want *QueuedError", err)
+	}
+	if tag != qerr.EntryID {
+		t.Errorf("incident metadata tag = %q, want %q", tag, qerr.EntryID)
+	}
+
+	if n, err := client.Outbox.Replay(context.Background(), client); err != nil || n != 1 {
+		t.Errorf("Outbox.Replay = %d, %v, want 1", n, err)
+	}
+}
+
+func TestOutbox_notQueued(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	setupOutbox(t, client)
+
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid status"}`, http.StatusUnprocessableEntity)
+	})
+	mux.HandleFunc("/v1/pages/1/components/web", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/v1/pages/1/components/db", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html>ok</html>`)
+	})
+
+	_, err := client.Component.UpdateComponent(context.Background(), "1", "api", statuspage.UpdateComponentParams{Status: statuspage.Value("broken")})
+	if _, ok := err.(*statuspage.QueuedError); ok || err == nil {
+		t.Errorf("ComponentService.UpdateComponent returned %v, want unqueued error", err)
+	}
+	if _, err := client.Component.GetComponent(context.Background(), "1", "web"); err == nil {
+		t.Error("ComponentService.GetComponent expected error")
+	}
+
+	// The update went through; only its response could not be read.
+	_, err = client.Component.UpdateComponent(context.Background(), "1", "db", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusDegraded)})
+	if _, ok := err.(*statuspage.QueuedError); ok || err == nil {
+		t.Errorf("ComponentService.UpdateComponent returned %v, want unqueued error", err)
+	}
+
+	if pending := client.Outbox.Pending(); len(pending) != 0 {
+		t.Errorf("Outbox.Pending = %+v, want none", pending)
+	}
+}
+
+func TestOutbox_bulkRollback(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	setupOutbox(t, client)
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"api","status":"operational"},{"id":"web","status":"operational"}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"api"}`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/web", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+
+	statuses := map[string]string{"api": statuspage.StatusMajorOutage, "web": statuspage.StatusMajorOutage}
+	opts := &statuspage.BulkUpdateOptions{Rollback: true}
+	results, err := client.Component.BulkUpdateStatus(context.Background(), "1", statuses, opts)
+	if err == nil {
+		t.Fatal("ComponentService.BulkUpdateStatus expected error")
+	}
+	if _, ok := results["web"].Err.(*statuspage.QueuedError); ok || !results["api"].RolledBack {
+		t.Errorf("ComponentService.BulkUpdateStatus results = %+v, %+v, want web unqueued and api rolled back", results["web"], results["api"])
+	}
+	if pending := client.Outbox.Pending(); len(pending) != 0 {
+		t.Fatalf("Outbox.Pending = %+v, want none", pending)
+	}
+
+	// Queue an entry, after which the bulk update is refused.
+	_, err = client.Component.UpdateComponent(context.Background(), "1", "web", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusDegraded)})
+	if _, ok := err.(*statuspage.QueuedError); !ok {
+		t.Fatalf("ComponentService.UpdateComponent returned %v, want *QueuedError", err)
+	}
+
+	results, _ = client.Component.BulkUpdateStatus(context.Background(), "1", statuses, opts)
+	if _, ok := results["api"].Err.(*statuspage.OutboxPendingError); !ok {
+		t.Errorf("ComponentService.BulkUpdateStatus result = %v, want *OutboxPendingError", results["api"].Err)
+	}
+	if pending := client.Outbox.Pending(); len(pending) != 1 {
+		t.Errorf("Outbox.Pending = %+v, want 1 entry", pending)
+	}
+}
+
+func TestOutbox_Drop(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	path := setupOutbox(t, client)
+
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+
+	_, err := client.Component.UpdateComponent(context.Background(), "1", "api", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusDegraded)})
+	qerr, ok := err.(*statuspage.QueuedError)
+	if !ok {
+		t.Fatalf("ComponentService.UpdateComponent returned %v, want *QueuedError", err)
+	}
+
+	if err := client.Outbox.Drop(qerr.EntryID); err != nil {
+		t.Fatalf("Outbox.Drop returned error: %v", err)
+	}
+	if err := client.Outbox.Drop(qerr.EntryID); err == nil {
+		t.Error("Outbox.Drop of a dropped entry expected error")
+	}
+
+	outbox, err := statuspage.OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("OpenOutbox returned error: %v", err)
+	}
+	if pending := outbox.Pending(); len(pending) != 0 {
+		t.Errorf("Outbox.Pending after drop = %+v, want none", pending)
+	}
+}
+
+func TestOutbox_replaySetsAsideRefused(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	setupOutbox(t, client)
+
+	deleted := false
+	mux.HandleFunc("/v1/pages/1/components/gone", func(w http.ResponseWriter, r *http.Request) {
+		if deleted {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+	var sent int
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		fmt.Fprint(w, `{"id":"api"}`)
+	})
+
+	_, err := client.Component.UpdateComponent(context.Background(), "1", "gone", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusDegraded)})
+	qerr, ok := err.(*statuspage.QueuedError)
+	if !ok {
+		t.Fatalf("ComponentService.UpdateComponent returned %v, want *QueuedError", err)
+	}
+
+	// The component is deleted before the replay, which the API refuses.
+	deleted = true
+	if n, err := client.Outbox.Replay(context.Background(), client); err != nil || n != 0 {
+		t.Errorf("Outbox.Replay = %d, %v, want 0 and no error", n, err)
+	}
+	pending := client.Outbox.Pending()
+	if len(pending) != 1 || pending[0].ID != qerr.EntryID || !pending[0].Failed {
+		t.Fatalf("Outbox.Pending = %+v, want %s failed", pending, qerr.EntryID)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Component.UpdateComponent(context.Background(), "1", "api", statuspage.UpdateComponentParams{Status: statuspage.Value(statuspage.StatusDegraded)})
+		if err != nil {
+			t.Errorf("ComponentService.UpdateComponent returned error: %v", err)
+		}
+	}
+	if sent != 3 {
+		t.Errorf("server received %d updates, want 3", sent)
+	}
+
+	// A failed entry is not replayed again.
+	if n, err := client.Outbox.Replay(context.Background(), client); err != nil || n != 0 {
+		t.Errorf("Outbox.Replay = %d, %v, want 0 and no error", n, err)
+	}
+	if pending := client.Outbox.Pending(); len(pending) != 1 || pending[0].Attempts != 2 {
+		t.Errorf("Outbox.Pending = %+v, want the failed entry at 2 attempts", pending)
+	}
+}
+
+func TestOutbox_lifecycleUpdate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	setupOutbox(t, client)
+
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"id":"i1","status":"investigating"}`)
+			return
+		}
+		http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+
+	_, err := client.Incident.Lifecycle("1").Identify(context.Background(), "i1", "Found it")
+	if _, ok := err.(*statuspage.QueuedError); !ok {
+		t.Fatalf("IncidentLifecycle.Identify returned %v, want *QueuedError", err)
+	}
+	if pending := client.Outbox.Pending(); len(pending) != 1 || pending[0].Operation != "IncidentService.UpdateIncident" {
+		t.Errorf("Outbox.Pending = %+v, want queued UpdateIncident", pending)
+	}
+}
@@ -2,6 +2,9 @@ package statuspage
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"strings"
 )
 
 // PageService handles communication with the page related methods
@@ -77,41 +80,43 @@ func (s *PageService) ListPages(ctx context.Context) (*[]Page, error) {
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "PageService.ListPages", "")
 	var pages []Page
 	_, err = s.client.do(ctx, req, &pages)
 
-	return &pages, err
+	return &pages, end(err)
 }
 
-// UpdatePageParams are the parameters that can be changed using the update page API endpoint
+// UpdatePageParams are the parameters that can be changed using the update page API endpoint.
+// Fields that are not set are left unchanged.
 type UpdatePageParams struct {
-	Name                     string `json:"name,omitempty"`
-	Domain                   string `json:"domain,omitempty"`
-	Subdomain                string `json:"subdomain,omitempty"`
-	URL                      string `json:"url,omitempty"`
-	Branding                 string `json:"branding,omitempty"`
-	CSSBodyBackgroundColor   string `json:"css_body_background_color,omitempty"`
-	CSSFontColor             string `json:"css_font_color,omitempty"`
-	CSSLightFontColor        string `json:"css_light_font_color,omitempty"`
-	CSSGreens                string `json:"css_greens,omitempty"`
-	CSSYellows               string `json:"css_yellows,omitempty"`
-	CSSOranges               string `json:"css_oranges,omitempty"`
-	CSSReds                  string `json:"css_reds,omitempty"`
-	CSSBlues                 string `json:"css_blues,omitempty"`
-	CSSBorderColor           string `json:"css_border_color,omitempty"`
-	CSSGraphColor            string `json:"css_graph_color,omitempty"`
-	CSSLinkColor             string `json:"css_link_color,omitempty"`
-	HiddenFromSearch         *bool  `json:"hidden_from_search,omitempty"`
-	ViewersMustBeTeamMembers *bool  `json:"viewers_must_be_team_members,omitempty"`
-	AllowPageSubscribers     *bool  `json:"allow_page_subscribers,omitempty"`
-	AllowIncidentSubscribers *bool  `json:"allow_incident_subscribers,omitempty"`
-	AllowEmailSubscribers    *bool  `json:"allow_email_subscribers,omitempty"`
-	AllowSmsSubscribers      *bool  `json:"allow_sms_subscribers,omitempty"`
-	AllowRssAtomFeeds        *bool  `json:"allow_rss_atom_feeds,omitempty"`
-	AllowWebhookSubscribers  *bool  `json:"allow_webhook_subscribers,omitempty"`
-	NotificationsFromEmail   string `json:"notifications_from_email,omitempty"`
-	TimeZone                 string `json:"time_zone,omitempty"`
-	NotificationsEmailFooter string `json:"notifications_email_footer,omitempty"`
+	Name                     Nullable[string] `json:"name,omitempty"`
+	Domain                   Nullable[string] `json:"domain,omitempty"`
+	Subdomain                Nullable[string] `json:"subdomain,omitempty"`
+	URL                      Nullable[string] `json:"url,omitempty"`
+	Branding                 Nullable[string] `json:"branding,omitempty"`
+	CSSBodyBackgroundColor   Nullable[string] `json:"css_body_background_color,omitempty"`
+	CSSFontColor             Nullable[string] `json:"css_font_color,omitempty"`
+	CSSLightFontColor        Nullable[string] `json:"css_light_font_color,omitempty"`
+	CSSGreens                Nullable[string] `json:"css_greens,omitempty"`
+	CSSYellows               Nullable[string] `json:"css_yellows,omitempty"`
+	CSSOranges               Nullable[string] `json:"css_oranges,omitempty"`
+	CSSReds                  Nullable[string] `json:"css_reds,omitempty"`
+	CSSBlues                 Nullable[string] `json:"css_blues,omitempty"`
+	CSSBorderColor           Nullable[string] `json:"css_border_color,omitempty"`
+	CSSGraphColor            Nullable[string] `json:"css_graph_color,omitempty"`
+	CSSLinkColor             Nullable[string] `json:"css_link_color,omitempty"`
+	HiddenFromSearch         Nullable[bool]   `json:"hidden_from_search,omitempty"`
+	ViewersMustBeTeamMembers Nullable[bool]   `json:"viewers_must_be_team_members,omitempty"`
+	AllowPageSubscribers     Nullable[bool]   `json:"allow_page_subscribers,omitempty"`
+	AllowIncidentSubscribers Nullable[bool]   `json:"allow_incident_subscribers,omitempty"`
+	AllowEmailSubscribers    Nullable[bool]   `json:"allow_email_subscribers,omitempty"`
+	AllowSmsSubscribers      Nullable[bool]   `json:"allow_sms_subscribers,omitempty"`
+	AllowRssAtomFeeds        Nullable[bool]   `json:"allow_rss_atom_feeds,omitempty"`
+	AllowWebhookSubscribers  Nullable[bool]   `json:"allow_webhook_subscribers,omitempty"`
+	NotificationsFromEmail   Nullable[string] `json:"notifications_from_email,omitempty"`
+	TimeZone                 Nullable[string] `json:"time_zone,omitempty"`
+	NotificationsEmailFooter Nullable[string] `json:"notifications_email_footer,omitempty"`
 }
 
 // UpdatePageRequestBody is the update page request body representation
@@ -128,10 +133,11 @@ func (s *PageService) UpdatePage(ctx context.Context, pageID string, page Update
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "PageService.UpdatePage", pageID)
 	var updatedPage Page
 	_, err = s.client.do(ctx, req, &updatedPage)
 
-	return &updatedPage, err
+	return &updatedPage, end(err)
 }
 
 // GetPage returns the page information for a given page id
@@ -142,8 +148,79 @@ func (s *PageService) GetPage(ctx context.Context, pageID string) (*Page, error)
 		return nil, err
 	}
 
+	ctx, end := s.client.startOperation(ctx, "PageService.GetPage", pageID)
 	var page Page
 	_, err = s.client.do(ctx, req, &page)
 
-	return &page, err
+	return &page, end(err)
+}
+
+// Page image types that can be uploaded with UploadPageImage
+const (
+	PageImageFaviconLogo       = "favicon_logo"
+	PageImageTransactionalLogo = "transactional_logo"
+	PageImageHeroCover         = "hero_cover"
+	PageImageEmailLogo         = "email_logo"
+	PageImageTwitterLogo       = "twitter_logo"
+)
+
+var imageExtensions = map[string]string{
+	"image/png":                ".png",
+	"image/jpeg":               ".jpg",
+	"image/gif":                ".gif",
+	"image/svg+xml":            ".svg",
+	"image/x-icon":             ".ico",
+	"image/vnd.microsoft.icon": ".ico",
+}
+
+// UploadPageImage uploads or replaces an image of a given page id. image is
+// one of the PageImage constants and contentType the image's MIME type,
+// such as "image/png".
+func (s *PageService) UploadPageImage(ctx context.Context, pageID, image string, r io.Reader, contentType string) (*Page, error) {
+	switch image {
+	case PageImageFaviconLogo, PageImageTransactionalLogo, PageImageHeroCover, PageImageEmailLogo, PageImageTwitterLogo:
+	default:
+		return nil, fmt.Errorf("statuspage: unknown page image %q", image)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("statuspage: content type %q is not an image", contentType)
+	}
+
+	path := "v1/pages/" + pageID
+	filename := image + imageExtensions[contentType]
+	req, err := s.client.newUploadRequest("PATCH", path, "page["+image+"]", filename, contentType, r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageService.UploadPageImage", pageID)
+	var updatedPage Page
+	_, err = s.client.do(ctx, req, &updatedPage)
+
+	return &updatedPage, end(err)
+}
+
+// UploadFaviconLogo uploads or replaces the favicon of a given page id
+func (s *PageService) UploadFaviconLogo(ctx context.Context, pageID string, r io.Reader, contentType string) (*Page, error) {
+	return s.UploadPageImage(ctx, pageID, PageImageFaviconLogo, r, contentType)
+}
+
+// UploadTransactionalLogo uploads or replaces the logo used in notifications of a given page id
+func (s *PageService) UploadTransactionalLogo(ctx context.Context, pageID string, r io.Reader, contentType string) (*Page, error) {
+	return s.UploadPageImage(ctx, pageID, PageImageTransactionalLogo, r, contentType)
+}
+
+// UploadHeroCover uploads or replaces the hero cover image of a given page id
+func (s *PageService) UploadHeroCover(ctx context.Context, pageID string, r io.Reader, contentType string) (*Page, error) {
+	return s.UploadPageImage(ctx, pageID, PageImageHeroCover, r, contentType)
+}
+
+// UploadEmailLogo uploads or replaces the email logo of a given page id
+func (s *PageService) UploadEmailLogo(ctx context.Context, pageID string, r io.Reader, contentType string) (*Page, error) {
+	return s.UploadPageImage(ctx, pageID, PageImageEmailLogo, r, contentType)
+}
+
+// UploadTwitterLogo uploads or replaces the Twitter logo of a given page id
+func (s *PageService) UploadTwitterLogo(ctx context.Context, pageID string, r io.Reader, contentType string) (*Page, error) {
+	return s.UploadPageImage(ctx, pageID, PageImageTwitterLogo, r, contentType)
 }
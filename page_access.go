@@ -0,0 +1,322 @@
+package statuspage
+
+import (
+	"context"
+)
+
+// PageAccessUserService handles communication with the page access user
+// related methods of the Statuspage API. Page access users can view
+// audience-specific pages.
+//
+// Statuspage API docs: https://developer.statuspage.io/#tag/page-access-users
+type PageAccessUserService service
+
+// PageAccessGroupService handles communication with the page access group
+// related methods of the Statuspage API.
+//
+// Statuspage API docs: https://developer.statuspage.io/#tag/page-access-groups
+type PageAccessGroupService service
+
+// PageAccessUser is the Statuspage API page access user representation
+type PageAccessUser struct {
+	ID                 string    `json:"id,omitempty"`
+	PageID             string    `json:"page_id,omitempty"`
+	Email              string    `json:"email,omitempty"`
+	ExternalLogin      string    `json:"external_login,omitempty"`
+	PageAccessGroupID  string    `json:"page_access_group_id,omitempty"`
+	PageAccessGroupIDs []string  `json:"page_access_group_ids,omitempty"`
+	CreatedAt          Timestamp `json:"created_at,omitempty"`
+	UpdatedAt          Timestamp `json:"updated_at,omitempty"`
+}
+
+func (u PageAccessUser) String() string {
+	return Stringify(u)
+}
+
+// PageAccessUserParams are the parameters used to create or update a page access user.
+// Fields that are not set are left unchanged.
+type PageAccessUserParams struct {
+	Email                 Nullable[string]   `json:"email,omitempty"`
+	ExternalLogin         Nullable[string]   `json:"external_login,omitempty"`
+	PageAccessGroupID     Nullable[string]   `json:"page_access_group_id,omitempty"`
+	PageAccessGroupIDs    Nullable[[]string] `json:"page_access_group_ids,omitempty"`
+	SubscribeToComponents Nullable[bool]     `json:"subscribe_to_components,omitempty"`
+}
+
+// PageAccessUserRequestBody is the create and update page access user request body representation
+type PageAccessUserRequestBody struct {
+	PageAccessUser PageAccessUserParams `json:"page_access_user"`
+}
+
+// PageAccessGroup is the Statuspage API page access group representation
+type PageAccessGroup struct {
+	ID                 string    `json:"id,omitempty"`
+	PageID             string    `json:"page_id,omitempty"`
+	Name               string    `json:"name,omitempty"`
+	ExternalIdentifier string    `json:"external_identifier,omitempty"`
+	PageAccessUserIDs  []string  `json:"page_access_user_ids,omitempty"`
+	ComponentIDs       []string  `json:"component_ids,omitempty"`
+	MetricIDs          []string  `json:"metric_ids,omitempty"`
+	CreatedAt          Timestamp `json:"created_at,omitempty"`
+	UpdatedAt          Timestamp `json:"updated_at,omitempty"`
+}
+
+func (g PageAccessGroup) String() string {
+	return Stringify(g)
+}
+
+// PageAccessGroupParams are the parameters used to create or update a page access group.
+// Fields that are not set are left unchanged.
+type PageAccessGroupParams struct {
+	Name               Nullable[string]   `json:"name,omitempty"`
+	ExternalIdentifier Nullable[string]   `json:"external_identifier,omitempty"`
+	PageAccessUserIDs  Nullable[[]string] `json:"page_access_user_ids,omitempty"`
+	ComponentIDs       Nullable[[]string] `json:"component_ids,omitempty"`
+	MetricIDs          Nullable[[]string] `json:"metric_ids,omitempty"`
+}
+
+// PageAccessGroupRequestBody is the create and update page access group request body representation
+type PageAccessGroupRequestBody struct {
+	PageAccessGroup PageAccessGroupParams `json:"page_access_group"`
+}
+
+// ComponentIDsRequestBody is the request body used to assign components
+type ComponentIDsRequestBody struct {
+	ComponentIDs []string `json:"component_ids"`
+}
+
+// MetricIDsRequestBody is the request body used to assign metrics
+type MetricIDsRequestBody struct {
+	MetricIDs []string `json:"metric_ids"`
+}
+
+// ListPageAccessUsers returns a list of page access users for a given page id
+func (s *PageAccessUserService) ListPageAccessUsers(ctx context.Context, pageID string, opts *ListOptions) ([]PageAccessUser, error) {
+	path := addOptions("v1/pages/"+pageID+"/page_access_users", opts)
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessUserService.ListPageAccessUsers", pageID)
+	var users []PageAccessUser
+	_, err = s.client.do(ctx, req, &users)
+
+	return users, end(err)
+}
+
+// GetPageAccessUser returns page access user information for a given page and user id
+func (s *PageAccessUserService) GetPageAccessUser(ctx context.Context, pageID string, userID string) (*PageAccessUser, error) {
+	path := "v1/pages/" + pageID + "/page_access_users/" + userID
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessUserService.GetPageAccessUser", pageID)
+	var user PageAccessUser
+	_, err = s.client.do(ctx, req, &user)
+
+	return &user, end(err)
+}
+
+// CreatePageAccessUser creates a page access user for a given page id
+func (s *PageAccessUserService) CreatePageAccessUser(ctx context.Context, pageID string, user PageAccessUserParams) (*PageAccessUser, error) {
+	path := "v1/pages/" + pageID + "/page_access_users"
+	payload := PageAccessUserRequestBody{PageAccessUser: user}
+	req, err := s.client.newRequest("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessUserService.CreatePageAccessUser", pageID)
+	var createdUser PageAccessUser
+	_, err = s.client.do(ctx, req, &createdUser)
+
+	return &createdUser, end(err)
+}
+
+// UpdatePageAccessUser updates a page access user for a given page and user id
+func (s *PageAccessUserService) UpdatePageAccessUser(ctx context.Context, pageID string, userID string, user PageAccessUserParams) (*PageAccessUser, error) {
+	path := "v1/pages/" + pageID + "/page_access_users/" + userID
+	payload := PageAccessUserRequestBody{PageAccessUser: user}
+	req, err := s.client.newRequest("PATCH", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessUserService.UpdatePageAccessUser", pageID)
+	var updatedUser PageAccessUser
+	_, err = s.client.do(ctx, req, &updatedUser)
+
+	return &updatedUser, end(err)
+}
+
+// DeletePageAccessUser deletes a page access user for a given page and user id
+func (s *PageAccessUserService) DeletePageAccessUser(ctx context.Context, pageID string, userID string) error {
+	path := "v1/pages/" + pageID + "/page_access_users/" + userID
+	req, err := s.client.newRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessUserService.DeletePageAccessUser", pageID)
+	_, err = s.client.do(ctx, req, nil)
+	return end(err)
+}
+
+// AddPageAccessUserComponents grants a page access user access to the given components
+func (s *PageAccessUserService) AddPageAccessUserComponents(ctx context.Context, pageID string, userID string, componentIDs []string) (*PageAccessUser, error) {
+	return s.assign(ctx, "AddPageAccessUserComponents", "PATCH", pageID, userID, "components", ComponentIDsRequestBody{ComponentIDs: componentIDs}, componentIDs...)
+}
+
+// ReplacePageAccessUserComponents replaces the components a page access user can access
+func (s *PageAccessUserService) ReplacePageAccessUserComponents(ctx context.Context, pageID string, userID string, componentIDs []string) (*PageAccessUser, error) {
+	return s.assign(ctx, "ReplacePageAccessUserComponents", "PUT", pageID, userID, "components", ComponentIDsRequestBody{ComponentIDs: componentIDs}, componentIDs...)
+}
+
+// RemovePageAccessUserComponents revokes a page access user's access to the given components
+func (s *PageAccessUserService) RemovePageAccessUserComponents(ctx context.Context, pageID string, userID string, componentIDs []string) (*PageAccessUser, error) {
+	return s.assign(ctx, "RemovePageAccessUserComponents", "DELETE", pageID, userID, "components", ComponentIDsRequestBody{ComponentIDs: componentIDs}, componentIDs...)
+}
+
+// AddPageAccessUserMetrics grants a page access user access to the given metrics
+func (s *PageAccessUserService) AddPageAccessUserMetrics(ctx context.Context, pageID string, userID string, metricIDs []string) (*PageAccessUser, error) {
+	return s.assign(ctx, "AddPageAccessUserMetrics", "PATCH", pageID, userID, "metrics", MetricIDsRequestBody{MetricIDs: metricIDs})
+}
+
+// ReplacePageAccessUserMetrics replaces the metrics a page access user can access
+func (s *PageAccessUserService) ReplacePageAccessUserMetrics(ctx context.Context, pageID string, userID string, metricIDs []string) (*PageAccessUser, error) {
+	return s.assign(ctx, "ReplacePageAccessUserMetrics", "PUT", pageID, userID, "metrics", MetricIDsRequestBody{MetricIDs: metricIDs})
+}
+
+// RemovePageAccessUserMetrics revokes a page access user's access to the given metrics
+func (s *PageAccessUserService) RemovePageAccessUserMetrics(ctx context.Context, pageID string, userID string, metricIDs []string) (*PageAccessUser, error) {
+	return s.assign(ctx, "RemovePageAccessUserMetrics", "DELETE", pageID, userID, "metrics", MetricIDsRequestBody{MetricIDs: metricIDs})
+}
+
+// assign sends payload to a page access user's resource for the
+// PageAccessUserService method operation
+func (s *PageAccessUserService) assign(ctx context.Context, operation, method, pageID, userID, resource string, payload interface{}, componentIDs ...string) (*PageAccessUser, error) {
+	path := "v1/pages/" + pageID + "/page_access_users/" + userID + "/" + resource
+	req, err := s.client.newRequest(method, path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessUserService."+operation, pageID, componentIDs...)
+	var user PageAccessUser
+	_, err = s.client.do(ctx, req, &user)
+
+	return &user, end(err)
+}
+
+// ListPageAccessGroups returns a list of page access groups for a given page id
+func (s *PageAccessGroupService) ListPageAccessGroups(ctx context.Context, pageID string, opts *ListOptions) ([]PageAccessGroup, error) {
+	path := addOptions("v1/pages/"+pageID+"/page_access_groups", opts)
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessGroupService.ListPageAccessGroups", pageID)
+	var groups []PageAccessGroup
+	_, err = s.client.do(ctx, req, &groups)
+
+	return groups, end(err)
+}
+
+// GetPageAccessGroup returns page access group information for a given page and group id
+func (s *PageAccessGroupService) GetPageAccessGroup(ctx context.Context, pageID string, groupID string) (*PageAccessGroup, error) {
+	path := "v1/pages/" + pageID + "/page_access_groups/" + groupID
+	req, err := s.client.newRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessGroupService.GetPageAccessGroup", pageID)
+	var group PageAccessGroup
+	_, err = s.client.do(ctx, req, &group)
+
+	return &group, end(err)
+}
+
+// CreatePageAccessGroup creates a page access group for a given page id
+func (s *PageAccessGroupService) CreatePageAccessGroup(ctx context.Context, pageID string, group PageAccessGroupParams) (*PageAccessGroup, error) {
+	path := "v1/pages/" + pageID + "/page_access_groups"
+	payload := PageAccessGroupRequestBody{PageAccessGroup: group}
+	req, err := s.client.newRequest("POST", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	componentIDs, _ := group.ComponentIDs.Get()
+	ctx, end := s.client.startOperation(ctx, "PageAccessGroupService.CreatePageAccessGroup", pageID, componentIDs...)
+	var createdGroup PageAccessGroup
+	_, err = s.client.do(ctx, req, &createdGroup)
+
+	return &createdGroup, end(err)
+}
+
+// UpdatePageAccessGroup updates a page access group for a given page and group id.
+// Metrics are assigned to a group through PageAccessGroupParams.MetricIDs.
+func (s *PageAccessGroupService) UpdatePageAccessGroup(ctx context.Context, pageID string, groupID string, group PageAccessGroupParams) (*PageAccessGroup, error) {
+	path := "v1/pages/" + pageID + "/page_access_groups/" + groupID
+	payload := PageAccessGroupRequestBody{PageAccessGroup: group}
+	req, err := s.client.newRequest("PATCH", path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	componentIDs, _ := group.ComponentIDs.Get()
+	ctx, end := s.client.startOperation(ctx, "PageAccessGroupService.UpdatePageAccessGroup", pageID, componentIDs...)
+	var updatedGroup PageAccessGroup
+	_, err = s.client.do(ctx, req, &updatedGroup)
+
+	return &updatedGroup, end(err)
+}
+
+// DeletePageAccessGroup deletes a page access group for a given page and group id
+func (s *PageAccessGroupService) DeletePageAccessGroup(ctx context.Context, pageID string, groupID string) error {
+	path := "v1/pages/" + pageID + "/page_access_groups/" + groupID
+	req, err := s.client.newRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessGroupService.DeletePageAccessGroup", pageID)
+	_, err = s.client.do(ctx, req, nil)
+	return end(err)
+}
+
+// AddPageAccessGroupComponents grants a page access group access to the given components
+func (s *PageAccessGroupService) AddPageAccessGroupComponents(ctx context.Context, pageID string, groupID string, componentIDs []string) (*PageAccessGroup, error) {
+	return s.assignComponents(ctx, "AddPageAccessGroupComponents", "PATCH", pageID, groupID, componentIDs)
+}
+
+// ReplacePageAccessGroupComponents replaces the components a page access group can access
+func (s *PageAccessGroupService) ReplacePageAccessGroupComponents(ctx context.Context, pageID string, groupID string, componentIDs []string) (*PageAccessGroup, error) {
+	return s.assignComponents(ctx, "ReplacePageAccessGroupComponents", "PUT", pageID, groupID, componentIDs)
+}
+
+// RemovePageAccessGroupComponents revokes a page access group's access to the given components
+func (s *PageAccessGroupService) RemovePageAccessGroupComponents(ctx context.Context, pageID string, groupID string, componentIDs []string) (*PageAccessGroup, error) {
+	return s.assignComponents(ctx, "RemovePageAccessGroupComponents", "DELETE", pageID, groupID, componentIDs)
+}
+
+// assignComponents sends the components of a page access group for the
+// PageAccessGroupService method operation
+func (s *PageAccessGroupService) assignComponents(ctx context.Context, operation, method, pageID, groupID string, componentIDs []string) (*PageAccessGroup, error) {
+	path := "v1/pages/" + pageID + "/page_access_groups/" + groupID + "/components"
+	payload := ComponentIDsRequestBody{ComponentIDs: componentIDs}
+	req, err := s.client.newRequest(method, path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, end := s.client.startOperation(ctx, "PageAccessGroupService."+operation, pageID, componentIDs...)
+	var group PageAccessGroup
+	_, err = s.client.do(ctx, req, &group)
+
+	return &group, end(err)
+}
@@ -0,0 +1,161 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestPageAccessUserService_ListPageAccessUsers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/page_access_users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("page"); got != "2" {
+			t.Errorf("page query = %q, want 2", got)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "50" {
+			t.Errorf("per_page query = %q, want 50", got)
+		}
+		fmt.Fprint(w, `[{"id":"u1","email":"a@example.com"}]`)
+	})
+
+	opts := &statuspage.ListOptions{Page: 2, PerPage: 50}
+	users, err := client.PageAccessUser.ListPageAccessUsers(context.Background(), "1", opts)
+	if err != nil {
+		t.Errorf("PageAccessUserService.ListPageAccessUsers returned error: %v", err)
+	}
+
+	want := []statuspage.PageAccessUser{{ID: "u1", Email: "a@example.com"}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("PageAccessUserService.ListPageAccessUsers returned %+v, want %+v", users, want)
+	}
+}
+
+func TestPageAccessUserService_CreatePageAccessUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := statuspage.PageAccessUserParams{
+		Email:             statuspage.Value("a@example.com"),
+		PageAccessGroupID: statuspage.Value("g1"),
+	}
+
+	mux.HandleFunc("/v1/pages/1/page_access_users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		v := &statuspage.PageAccessUserRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.PageAccessUser, input) {
+			t.Errorf("Request body = %+v, want %+v", v.PageAccessUser, input)
+		}
+
+		fmt.Fprint(w, `{"id":"u1","email":"a@example.com"}`)
+	})
+
+	user, err := client.PageAccessUser.CreatePageAccessUser(context.Background(), "1", input)
+	if err != nil {
+		t.Errorf("PageAccessUserService.CreatePageAccessUser returned error: %v", err)
+	}
+
+	want := &statuspage.PageAccessUser{ID: "u1", Email: "a@example.com"}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("PageAccessUserService.CreatePageAccessUser returned %+v, want %+v", user, want)
+	}
+}
+
+func TestPageAccessUserService_DeletePageAccessUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/page_access_users/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{}`)
+	})
+
+	err := client.PageAccessUser.DeletePageAccessUser(context.Background(), "1", "u1")
+	if err != nil {
+		t.Errorf("PageAccessUserService.DeletePageAccessUser returned error: %v", err)
+	}
+}
+
+func TestPageAccessUserService_AddPageAccessUserMetrics(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/page_access_users/u1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		v := &statuspage.MetricIDsRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		if want := []string{"m1", "m2"}; !reflect.DeepEqual(v.MetricIDs, want) {
+			t.Errorf("Request metric ids = %v, want %v", v.MetricIDs, want)
+		}
+
+		fmt.Fprint(w, `{"id":"u1"}`)
+	})
+
+	_, err := client.PageAccessUser.AddPageAccessUserMetrics(context.Background(), "1", "u1", []string{"m1", "m2"})
+	if err != nil {
+		t.Errorf("PageAccessUserService.AddPageAccessUserMetrics returned error: %v", err)
+	}
+}
+
+func TestPageAccessGroupService_UpdatePageAccessGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := statuspage.PageAccessGroupParams{
+		Name:         statuspage.Value("Acme Corp"),
+		ComponentIDs: statuspage.Value([]string{"c1"}),
+		MetricIDs:    statuspage.Value([]string{"m1"}),
+	}
+
+	mux.HandleFunc("/v1/pages/1/page_access_groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		v := &statuspage.PageAccessGroupRequestBody{}
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.PageAccessGroup, input) {
+			t.Errorf("Request body = %+v, want %+v", v.PageAccessGroup, input)
+		}
+
+		fmt.Fprint(w, `{"id":"g1","name":"Acme Corp","component_ids":["c1"],"metric_ids":["m1"]}`)
+	})
+
+	group, err := client.PageAccessGroup.UpdatePageAccessGroup(context.Background(), "1", "g1", input)
+	if err != nil {
+		t.Errorf("PageAccessGroupService.UpdatePageAccessGroup returned error: %v", err)
+	}
+
+	want := &statuspage.PageAccessGroup{ID: "g1", Name: "Acme Corp", ComponentIDs: []string{"c1"}, MetricIDs: []string{"m1"}}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("PageAccessGroupService.UpdatePageAccessGroup returned %+v, want %+v", group, want)
+	}
+}
+
+func TestPageAccessGroupService_ReplacePageAccessGroupComponents(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/page_access_groups/g1/components", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"id":"g1","component_ids":["c2"]}`)
+	})
+
+	group, err := client.PageAccessGroup.ReplacePageAccessGroupComponents(context.Background(), "1", "g1", []string{"c2"})
+	if err != nil {
+		t.Errorf("PageAccessGroupService.ReplacePageAccessGroupComponents returned error: %v", err)
+	}
+
+	want := &statuspage.PageAccessGroup{ID: "g1", ComponentIDs: []string{"c2"}}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("PageAccessGroupService.ReplacePageAccessGroupComponents returned %+v, want %+v", group, want)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	statuspage "github.com/isaaclimdc/statuspage-go"
@@ -199,9 +201,10 @@ func TestPageService_UpdatePage(t *testing.T) {
 	defer teardown()
 
 	input := statuspage.UpdatePageParams{
-		Name:                     "a",
-		HiddenFromSearch:         Bool(false),
-		ViewersMustBeTeamMembers: Bool(true),
+		Name:                     statuspage.Value("a"),
+		Domain:                   statuspage.Null[string](),
+		HiddenFromSearch:         statuspage.Value(false),
+		ViewersMustBeTeamMembers: statuspage.Value(true),
 	}
 
 	mux.HandleFunc("/v1/pages/1", func(w http.ResponseWriter, r *http.Request) {
@@ -228,3 +231,53 @@ func TestPageService_UpdatePage(t *testing.T) {
 		t.Errorf("PageService.UpdatePage returned %+v, want %+v", page, want)
 	}
 }
+
+func TestPageService_UploadFaviconLogo(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		file, header, err := r.FormFile("page[favicon_logo]")
+		if err != nil {
+			t.Errorf("FormFile returned error: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		if got := header.Header.Get("Content-Type"); got != "image/png" {
+			t.Errorf("part Content-Type = %q, want image/png", got)
+		}
+		if header.Filename != "favicon_logo.png" {
+			t.Errorf("part filename = %q, want favicon_logo.png", header.Filename)
+		}
+		if data, _ := io.ReadAll(file); string(data) != "PNGDATA" {
+			t.Errorf("part data = %q, want PNGDATA", data)
+		}
+
+		fmt.Fprint(w, `{"id":"1","favicon_logo":{"url":"u"}}`)
+	})
+
+	page, err := client.Page.UploadFaviconLogo(context.Background(), "1", strings.NewReader("PNGDATA"), "image/png")
+	if err != nil {
+		t.Errorf("PageService.UploadFaviconLogo returned error: %v", err)
+	}
+
+	want := &statuspage.Page{ID: String("1"), FaviconLogo: &statuspage.PageLogo{URL: String("u")}}
+	if !reflect.DeepEqual(page, want) {
+		t.Errorf("PageService.UploadFaviconLogo returned %+v, want %+v", page, want)
+	}
+}
+
+func TestPageService_UploadPageImage_invalid(t *testing.T) {
+	client := statuspage.NewClient("test-token", nil)
+
+	if _, err := client.Page.UploadPageImage(context.Background(), "1", "banner", strings.NewReader(""), "image/png"); err == nil {
+		t.Error("PageService.UploadPageImage with unknown image expected error")
+	}
+	if _, err := client.Page.UploadPageImage(context.Background(), "1", statuspage.PageImageHeroCover, strings.NewReader(""), "text/plain"); err == nil {
+		t.Error("PageService.UploadPageImage with non-image content type expected error")
+	}
+}
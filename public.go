@@ -0,0 +1,190 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const publicHostSuffix = ".statuspage.io"
+
+// A PublicClient reads the unauthenticated status API that Statuspage serves
+// from each page's own domain. No API key is required, which makes it
+// suitable for showing the status of third-party pages.
+//
+// Statuspage API docs: https://metastatuspage.com/api
+type PublicClient struct {
+	client *Client
+}
+
+// PublicPage is the page information included in every public API response
+type PublicPage struct {
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	TimeZone  string    `json:"time_zone,omitempty"`
+	UpdatedAt Timestamp `json:"updated_at,omitempty"`
+}
+
+// PublicStatus is the rollup status of a page as reported by the public API
+type PublicStatus struct {
+	Indicator   string `json:"indicator,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PublicStatusResponse is the public status.json representation
+type PublicStatusResponse struct {
+	Page   PublicPage   `json:"page"`
+	Status PublicStatus `json:"status"`
+}
+
+// PublicSummary is the public summary.json representation
+type PublicSummary struct {
+	Page                  PublicPage   `json:"page"`
+	Status                PublicStatus `json:"status"`
+	Components            []Component  `json:"components"`
+	Incidents             []Incident   `json:"incidents"`
+	ScheduledMaintenances []Incident   `json:"scheduled_maintenances"`
+}
+
+// PublicComponents is the public components.json representation
+type PublicComponents struct {
+	Page       PublicPage  `json:"page"`
+	Components []Component `json:"components"`
+}
+
+// PublicIncidents is the public incidents representation
+type PublicIncidents struct {
+	Page      PublicPage `json:"page"`
+	Incidents []Incident `json:"incidents"`
+}
+
+// PublicScheduledMaintenances is the public scheduled maintenances representation
+type PublicScheduledMaintenances struct {
+	Page                  PublicPage `json:"page"`
+	ScheduledMaintenances []Incident `json:"scheduled_maintenances"`
+}
+
+// NewPublicClient returns a client for the public status API of a single
+// page. The page may be given as a full URL (as found in Page.URL), a bare
+// host name, or a statuspage.io subdomain. If a nil httpClient is provided,
+// http.DefaultClient will be used.
+func NewPublicClient(page string, httpClient *http.Client) (*PublicClient, error) {
+	baseURL, err := publicBaseURL(page)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewClient("", httpClient)
+	c.BaseURL = baseURL
+
+	return &PublicClient{client: c}, nil
+}
+
+func publicBaseURL(page string) (*url.URL, error) {
+	page = strings.TrimSpace(page)
+	if page == "" {
+		return nil, fmt.Errorf("statuspage: empty page URL")
+	}
+
+	if !strings.Contains(page, "://") {
+		if !strings.Contains(page, ".") {
+			page += publicHostSuffix
+		}
+		page = "https://" + page
+	}
+
+	u, err := url.Parse(page)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("statuspage: invalid page URL %q", page)
+	}
+
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return u, nil
+}
+
+// BaseURL returns the page URL that API paths are resolved against
+func (p *PublicClient) BaseURL() *url.URL {
+	return p.client.BaseURL
+}
+
+func (p *PublicClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := p.client.newRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.client.do(ctx, req, v)
+	return err
+}
+
+// Summary returns the page status, components, unresolved incidents and
+// upcoming or in-progress scheduled maintenances
+func (p *PublicClient) Summary(ctx context.Context) (*PublicSummary, error) {
+	var summary PublicSummary
+	err := p.get(ctx, "api/v2/summary.json", &summary)
+
+	return &summary, err
+}
+
+// Status returns the rollup status of the page
+func (p *PublicClient) Status(ctx context.Context) (*PublicStatusResponse, error) {
+	var status PublicStatusResponse
+	err := p.get(ctx, "api/v2/status.json", &status)
+
+	return &status, err
+}
+
+// Components returns the components of the page
+func (p *PublicClient) Components(ctx context.Context) (*PublicComponents, error) {
+	var components PublicComponents
+	err := p.get(ctx, "api/v2/components.json", &components)
+
+	return &components, err
+}
+
+// UnresolvedIncidents returns the incidents that are not yet resolved
+func (p *PublicClient) UnresolvedIncidents(ctx context.Context) (*PublicIncidents, error) {
+	var incidents PublicIncidents
+	err := p.get(ctx, "api/v2/incidents/unresolved.json", &incidents)
+
+	return &incidents, err
+}
+
+// Incidents returns the 50 most recent incidents
+func (p *PublicClient) Incidents(ctx context.Context) (*PublicIncidents, error) {
+	var incidents PublicIncidents
+	err := p.get(ctx, "api/v2/incidents.json", &incidents)
+
+	return &incidents, err
+}
+
+// UpcomingScheduledMaintenances returns scheduled maintenances that have not started yet
+func (p *PublicClient) UpcomingScheduledMaintenances(ctx context.Context) (*PublicScheduledMaintenances, error) {
+	return p.scheduledMaintenances(ctx, "api/v2/scheduled-maintenances/upcoming.json")
+}
+
+// ActiveScheduledMaintenances returns scheduled maintenances that are in progress or verifying
+func (p *PublicClient) ActiveScheduledMaintenances(ctx context.Context) (*PublicScheduledMaintenances, error) {
+	return p.scheduledMaintenances(ctx, "api/v2/scheduled-maintenances/active.json")
+}
+
+// ScheduledMaintenances returns the 50 most recent scheduled maintenances
+func (p *PublicClient) ScheduledMaintenances(ctx context.Context) (*PublicScheduledMaintenances, error) {
+	return p.scheduledMaintenances(ctx, "api/v2/scheduled-maintenances.json")
+}
+
+func (p *PublicClient) scheduledMaintenances(ctx context.Context, path string) (*PublicScheduledMaintenances, error) {
+	var maintenances PublicScheduledMaintenances
+	err := p.get(ctx, path, &maintenances)
+
+	return &maintenances, err
+}
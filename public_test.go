@@ -0,0 +1,134 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func setupPublic(t *testing.T) (client *statuspage.PublicClient, mux *http.ServeMux, teardown func()) {
+	_, mux, serverURL, teardown := setup()
+
+	client, err := statuspage.NewPublicClient(serverURL+baseURLPath, nil)
+	if err != nil {
+		t.Fatalf("NewPublicClient returned error: %v", err)
+	}
+
+	return client, mux, teardown
+}
+
+func TestNewPublicClient_baseURL(t *testing.T) {
+	tests := map[string]string{
+		"acme":                          "https://acme.statuspage.io/",
+		"status.example.com":            "https://status.example.com/",
+		"https://status.example.com":    "https://status.example.com/",
+		"http://acme.statuspage.io/sub": "http://acme.statuspage.io/sub/",
+	}
+
+	for page, want := range tests {
+		client, err := statuspage.NewPublicClient(page, nil)
+		if err != nil {
+			t.Errorf("NewPublicClient(%q) returned error: %v", page, err)
+			continue
+		}
+		if got := client.BaseURL().String(); got != want {
+			t.Errorf("NewPublicClient(%q) base URL = %q, want %q", page, got, want)
+		}
+	}
+
+	if _, err := statuspage.NewPublicClient("", nil); err == nil {
+		t.Error("NewPublicClient with empty page expected error")
+	}
+}
+
+func TestPublicClient_Summary(t *testing.T) {
+	client, mux, teardown := setupPublic(t)
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/summary.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization header = %q, want none", got)
+		}
+		fmt.Fprint(w, `{
+			"page": {"id": "p", "name": "Acme", "updated_at": "2006-01-02T15:04:05.000Z"},
+			"status": {"indicator": "minor", "description": "Partially Degraded Service"},
+			"components": [{"id": "c1", "status": "degraded_performance", "start_date": null}],
+			"incidents": [{"id": "i1", "impact": "minor", "resolved_at": null,
+				"incident_updates": [{"id": "u1", "status": "investigating", "body": "Looking"}]}],
+			"scheduled_maintenances": []
+		}`)
+	})
+
+	summary, err := client.Summary(context.Background())
+	if err != nil {
+		t.Fatalf("PublicClient.Summary returned error: %v", err)
+	}
+
+	want := &statuspage.PublicSummary{
+		Page:       statuspage.PublicPage{ID: "p", Name: "Acme", UpdatedAt: statuspage.Timestamp{referenceTime}},
+		Status:     statuspage.PublicStatus{Indicator: "minor", Description: "Partially Degraded Service"},
+		Components: []statuspage.Component{{ID: "c1", Status: statuspage.StatusDegraded}},
+		Incidents: []statuspage.Incident{{
+			ID:              "i1",
+			Impact:          "minor",
+			IncidentUpdates: []statuspage.IncidentHistoryEntry{{ID: "u1", Status: statuspage.StatusInvestigating, Body: "Looking"}},
+		}},
+		ScheduledMaintenances: []statuspage.Incident{},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("PublicClient.Summary returned %+v, want %+v", summary, want)
+	}
+}
+
+func TestPublicClient_Status(t *testing.T) {
+	client, mux, teardown := setupPublic(t)
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/status.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"page": {"id": "p"}, "status": {"indicator": "none", "description": "All Systems Operational"}}`)
+	})
+
+	status, err := client.Status(context.Background())
+	if err != nil {
+		t.Fatalf("PublicClient.Status returned error: %v", err)
+	}
+
+	want := &statuspage.PublicStatusResponse{
+		Page:   statuspage.PublicPage{ID: "p"},
+		Status: statuspage.PublicStatus{Indicator: "none", Description: "All Systems Operational"},
+	}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("PublicClient.Status returned %+v, want %+v", status, want)
+	}
+}
+
+func TestPublicClient_UpcomingScheduledMaintenances(t *testing.T) {
+	client, mux, teardown := setupPublic(t)
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/scheduled-maintenances/upcoming.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"page": {"id": "p"}, "scheduled_maintenances": [{"id": "m1", "status": "scheduled", "scheduled_for": "2006-01-02T15:04:05Z"}]}`)
+	})
+
+	maintenances, err := client.UpcomingScheduledMaintenances(context.Background())
+	if err != nil {
+		t.Fatalf("PublicClient.UpcomingScheduledMaintenances returned error: %v", err)
+	}
+
+	want := &statuspage.PublicScheduledMaintenances{
+		Page: statuspage.PublicPage{ID: "p"},
+		ScheduledMaintenances: []statuspage.Incident{
+			{ID: "m1", Status: "scheduled", ScheduledFor: &statuspage.Timestamp{referenceTime}},
+		},
+	}
+	if !reflect.DeepEqual(maintenances, want) {
+		t.Errorf("PublicClient.UpcomingScheduledMaintenances returned %+v, want %+v", maintenances, want)
+	}
+}
@@ -0,0 +1,139 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ComponentSnapshot records the status of every component of a page so it
+// can be restored later, e.g. after maintenance. It is meant to be saved as
+// JSON. Group entries are left out since their status follows their
+// components.
+type ComponentSnapshot struct {
+	PageID     string              `json:"page_id"`
+	TakenAt    Timestamp           `json:"taken_at"`
+	Components []SnapshotComponent `json:"components"`
+}
+
+// SnapshotComponent is the recorded state of a single component
+type SnapshotComponent struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"`
+}
+
+// ComponentChange is a component whose status differs from a snapshot
+type ComponentChange struct {
+	ComponentID string
+	Name        string
+	Current     string
+	Desired     string
+}
+
+func (c ComponentChange) String() string {
+	return fmt.Sprintf("%s (%s): %q -> %q", c.Name, c.ComponentID, c.Current, c.Desired)
+}
+
+// RestoreOptions specifies the optional parameters to the RestoreComponents method
+type RestoreOptions struct {
+	// DryRun only reports the changes a restore would make.
+	DryRun bool
+}
+
+// SnapshotComponents records the current status of the components of a given page id
+func (c *Client) SnapshotComponents(ctx context.Context, pageID string) (_ *ComponentSnapshot, err error) {
+	if pageID == "" {
+		pageID = c.defaultPage
+	}
+
+	ctx, end := c.startOperation(ctx, "Client.SnapshotComponents", pageID)
+	defer func() { end(err) }()
+
+	components, err := c.Component.ListComponents(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &ComponentSnapshot{
+		PageID:     pageID,
+		TakenAt:    Timestamp{time.Now().UTC()},
+		Components: make([]SnapshotComponent, 0, len(components)),
+	}
+	for _, component := range components {
+		if component.Group {
+			continue
+		}
+		snapshot.Components = append(snapshot.Components, SnapshotComponent{
+			ID:     component.ID,
+			Name:   component.Name,
+			Status: component.Status,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// RestoreComponents puts the components of a page back to the statuses in a
+// snapshot. Only components whose status differs are updated, and those are
+// the changes returned. Components that no longer exist are skipped and
+// reported in the returned ComponentErrors, as are failed updates.
+func (c *Client) RestoreComponents(ctx context.Context, snapshot *ComponentSnapshot, opts *RestoreOptions) (_ []ComponentChange, err error) {
+	ctx, end := c.startOperation(ctx, "Client.RestoreComponents", snapshot.PageID)
+	defer func() { end(err) }()
+
+	if opts == nil {
+		opts = &RestoreOptions{}
+	}
+
+	components, err := c.Component.ListComponents(ctx, snapshot.PageID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]Component, len(components))
+	for _, component := range components {
+		current[component.ID] = component
+	}
+
+	changes := make([]ComponentChange, 0)
+	failed := make(ComponentErrors)
+	for _, saved := range snapshot.Components {
+		component, ok := current[saved.ID]
+		if !ok {
+			failed[saved.ID] = fmt.Errorf("component %q no longer exists", saved.Name)
+			continue
+		}
+
+		if component.Status != saved.Status {
+			changes = append(changes, ComponentChange{
+				ComponentID: saved.ID,
+				Name:        component.Name,
+				Current:     component.Status,
+				Desired:     saved.Status,
+			})
+		}
+	}
+
+	if !opts.DryRun && len(changes) > 0 {
+		statuses := make(map[string]string, len(changes))
+		for _, change := range changes {
+			statuses[change.ComponentID] = change.Desired
+		}
+
+		_, err := c.Component.BulkUpdateStatus(ctx, snapshot.PageID, statuses, nil)
+		if errs, ok := err.(ComponentErrors); ok {
+			for id, err := range errs {
+				failed[id] = err
+			}
+		} else if err != nil {
+			return changes, err
+		}
+	}
+
+	if len(failed) > 0 {
+		return changes, failed
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,94 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestClient_SnapshotComponents(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"g1","name":"EU","group":true,"status":"operational"},{"id":"api","name":"API","status":"degraded_performance"}]`)
+	})
+
+	snapshot, err := client.SnapshotComponents(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Client.SnapshotComponents returned error: %v", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var decoded statuspage.ComponentSnapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	want := []statuspage.SnapshotComponent{{ID: "api", Name: "API", Status: statuspage.StatusDegraded}}
+	if decoded.PageID != "1" || !decoded.TakenAt.Equal(snapshot.TakenAt) || !reflect.DeepEqual(decoded.Components, want) {
+		t.Errorf("decoded snapshot = %+v, want components %+v", decoded, want)
+	}
+}
+
+func TestClient_RestoreComponents(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"api","name":"API","status":"under_maintenance"},{"id":"web","name":"Web","status":"operational"}]`)
+	})
+
+	updates := 0
+	mux.HandleFunc("/v1/pages/1/components/api", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		updates++
+		fmt.Fprint(w, `{"id":"api","status":"operational"}`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/web", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unchanged component should not be updated")
+	})
+
+	snapshot := &statuspage.ComponentSnapshot{
+		PageID: "1",
+		Components: []statuspage.SnapshotComponent{
+			{ID: "api", Name: "API", Status: statuspage.StatusOperational},
+			{ID: "web", Name: "Web", Status: statuspage.StatusOperational},
+		},
+	}
+	want := []statuspage.ComponentChange{
+		{ComponentID: "api", Name: "API", Current: statuspage.StatusMaintenance, Desired: statuspage.StatusOperational},
+	}
+
+	changes, err := client.RestoreComponents(context.Background(), snapshot, &statuspage.RestoreOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Client.RestoreComponents returned error: %v", err)
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Client.RestoreComponents returned %+v, want %+v", changes, want)
+	}
+	if updates != 0 {
+		t.Errorf("Client.RestoreComponents dry run made %d updates", updates)
+	}
+
+	changes, err = client.RestoreComponents(context.Background(), snapshot, nil)
+	if err != nil {
+		t.Fatalf("Client.RestoreComponents returned error: %v", err)
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Client.RestoreComponents returned %+v, want %+v", changes, want)
+	}
+	if updates != 1 {
+		t.Errorf("Client.RestoreComponents made %d updates, want 1", updates)
+	}
+}
@@ -4,14 +4,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const version = "1.0.0"
 const hostURL = "api.statuspage.io"
+const defaultConcurrency = 4
 
 // A Client manages communication with the Statuspage API.
 type Client struct {
@@ -25,6 +34,27 @@ type Client struct {
 
 	defaultPage string
 
+	// Concurrency bounds the number of requests that helpers fanning out over
+	// many resources make at once. Defaults to 4 when unset.
+	Concurrency int
+
+	// Cache is the response cache used by clients created with
+	// NewCachedClient, and nil otherwise.
+	Cache *ResponseCache
+
+	// Instrumentation, if set, observes every request for metrics or tracing.
+	Instrumentation Instrumentation
+
+	// Outbox, if set, keeps failed mutating requests so they can be replayed
+	// once the API is reachable again.
+	Outbox *Outbox
+
+	// DedupeCache, if set, keeps CreateIncidentOnce calls with the same
+	// dedupe key from racing each other.
+	DedupeCache *DedupeCache
+
+	names nameIndex
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the Statuspage API.
@@ -32,47 +62,154 @@ type Client struct {
 	Component *ComponentService
 	Group     *GroupService
 	Incident  *IncidentService
+
+	PageAccessUser  *PageAccessUserService
+	PageAccessGroup *PageAccessGroupService
+	Organization    *OrganizationService
 }
 
 type service struct {
 	client *Client
 }
 
+// ListOptions specifies the optional parameters to methods that support pagination
+type ListOptions struct {
+	// For paginated result sets, page of results to retrieve.
+	Page int
+	// For paginated result sets, the number of results to include per page.
+	PerPage int
+}
+
+func (o *ListOptions) values() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	return q
+}
+
+// addOptions adds the pagination parameters in opts as URL query parameters to path
+func addOptions(path string, opts *ListOptions) string {
+	return addQuery(path, opts.values())
+}
+
+func addQuery(path string, q url.Values) string {
+	if len(q) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
+
 func (c *Client) SetDefaultPage(page string) {
 	c.defaultPage = page
 }
 
 func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
-	rel := &url.URL{Path: path}
-	u := c.BaseURL.ResolveReference(rel)
-	var buf io.ReadWriter
-	if body != nil {
+	if body == nil {
+		return c.newRawRequest(method, path, "", nil)
+	}
+
+	buf := new(bytes.Buffer)
+	err := json.NewEncoder(buf).Encode(body)
+	if err != nil {
+		return nil, err
+	}
 
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
+	return c.newRawRequest(method, path, "application/json", buf)
+}
 
-		// buf2 := new(bytes.Buffer)
-		// json.NewEncoder(buf2).Encode(body)
+// newUploadRequest creates a multipart/form-data request carrying a single
+// file part, read from r, under the given form field
+func (c *Client) newUploadRequest(method, path, field, filename, contentType string, r io.Reader) (*http.Request, error) {
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
 
-		// fmt.Printf("REQ: %s", buf2.String())
-		if err != nil {
-			return nil, err
-		}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, filename))
+	header.Set("Content-Type", contentType)
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return c.newRawRequest(method, path, mw.FormDataContentType(), buf)
+}
+
+func (c *Client) newRawRequest(method, path, contentType string, body io.Reader) (*http.Request, error) {
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, err
 	}
-	req, err := http.NewRequest(method, u.String(), buf)
+	u := c.BaseURL.ResolveReference(rel)
+
+	req, err := http.NewRequest(method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "OAuth "+c.Token)
+	if c.Token != "" {
+		req.Header.Set("Authorization", "OAuth "+c.Token)
+	}
 
 	return req, nil
 }
 
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	if c.Outbox != nil && !isReplay(ctx) {
+		if op := operationFromContext(ctx); op != nil {
+			if operation := operationName(op.Service, op.Operation); c.Outbox.tracks(operation) {
+				return c.Outbox.send(ctx, c, operation, req, v)
+			}
+		}
+	}
+
+	return c.observe(ctx, req, v)
+}
+
+// observe sends req, reporting it to the client's Instrumentation
+func (c *Client) observe(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, v)
+	}
+
+	ctx, finish := c.Instrumentation.StartRequest(ctx, newRequestInfo(ctx, req))
+
+	start := time.Now()
+	resp, err := c.send(ctx, req, v)
+	info := &ResponseInfo{Duration: time.Since(start), Err: err, RateLimitRemaining: -1}
+
+	received := resp
+	var rerr *ErrorResponse
+	if received == nil && errors.As(err, &rerr) {
+		received = rerr.Response
+	}
+	if received != nil {
+		info.StatusCode = received.StatusCode
+		info.RateLimitRemaining = rateLimitRemaining(received)
+	}
+
+	finish(info)
+	return resp, err
+}
+
+// send sends req and decodes the JSON response body into v
+func (c *Client) send(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	newReq := req.WithContext(ctx)
 
 	resp, err := c.httpClient.Do(newReq)
@@ -112,7 +249,17 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*htt
 		return nil, fmt.Errorf("error reading response body: %s", err)
 	}
 
-	return nil, fmt.Errorf("response %s: %d – %s", resp.Status, resp.StatusCode, string(body))
+	return nil, &ErrorResponse{Response: resp, Body: body}
+}
+
+// ErrorResponse reports an error response from the Statuspage API
+type ErrorResponse struct {
+	Response *http.Response
+	Body     []byte
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("response %s: %d – %s", e.Response.Status, e.Response.StatusCode, string(e.Body))
 }
 
 // NewClient returns a new Statuspage API client. If a nil httpClient is
@@ -136,11 +283,16 @@ func NewClient(token string, httpClient *http.Client) *Client {
 	c.Component = (*ComponentService)(&c.common)
 	c.Group = (*GroupService)(&c.common)
 	c.Incident = (*IncidentService)(&c.common)
+	c.PageAccessUser = (*PageAccessUserService)(&c.common)
+	c.PageAccessGroup = (*PageAccessGroupService)(&c.common)
+	c.Organization = (*OrganizationService)(&c.common)
 
 	return c
 }
 
-func (c *Client) GetAllGroupsAndComponents(ctx context.Context, pageID string) (map[string]Group, error) {
+func (c *Client) GetAllGroupsAndComponents(ctx context.Context, pageID string) (_ map[string]Group, err error) {
+	ctx, end := c.startOperation(ctx, "Client.GetAllGroupsAndComponents", pageID)
+	defer func() { end(err) }()
 
 	groupMap := make(map[string]Group, 0)
 
@@ -171,24 +323,128 @@ func (c *Client) GetAllGroupsAndComponents(ctx context.Context, pageID string) (
 	return groupMap, nil
 }
 
-func (c *Client) GetComponentsFromGroup(ctx context.Context, pageID, groupID string) ([]Component, error) {
-
-	components := make([]Component, 0)
+// GetComponentsFromGroup returns the components of a group, in the order the
+// group lists them. Components are taken from a single ListComponents call
+// where possible; any that it does not return are fetched individually,
+// at most Client.Concurrency at a time. If the list call fails with any
+// error other than a 404 or a server error, that error is returned.
+// Components that cannot be fetched are reported in a ComponentErrors
+// alongside the ones that could.
+func (c *Client) GetComponentsFromGroup(ctx context.Context, pageID, groupID string) (_ []Component, err error) {
+	ctx, end := c.startOperation(ctx, "Client.GetComponentsFromGroup", pageID)
+	defer func() { end(err) }()
 
 	group, err := c.Group.GetGroup(ctx, pageID, groupID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, comp := range group.Components {
+	found := make(map[string]Component, len(group.Components))
+
+	listed, err := c.Component.ListComponents(ctx, pageID)
+	if err != nil && !canFetchIndividually(err) {
+		return nil, err
+	}
+	for _, comp := range listed {
+		found[comp.ID] = comp
+	}
+
+	missing := make([]string, 0)
+	for _, id := range group.Components {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
 
-		component, err := c.Component.GetComponent(ctx, pageID, comp)
-		if err != nil {
-			return nil, err
+	fetched := make([]*Component, len(missing))
+	errs := make([]error, len(missing))
+	c.parallel(len(missing), func(i int) {
+		fetched[i], errs[i] = c.Component.GetComponent(ctx, pageID, missing[i])
+	})
+
+	failed := make(ComponentErrors)
+	for i, id := range missing {
+		if errs[i] != nil {
+			failed[id] = errs[i]
+			continue
 		}
+		found[id] = *fetched[i]
+	}
 
-		components = append(components, *component)
+	components := make([]Component, 0, len(group.Components))
+	for _, id := range group.Components {
+		if comp, ok := found[id]; ok {
+			components = append(components, comp)
+		}
+	}
+
+	if len(failed) > 0 {
+		return components, failed
 	}
 
 	return components, nil
 }
+
+// canFetchIndividually reports whether a failed ListComponents call may be
+// made up for by fetching components one by one: the list was not found or
+// the server failed, as opposed to the request being refused or canceled
+func canFetchIndividually(err error) bool {
+	var rerr *ErrorResponse
+	if !errors.As(err, &rerr) {
+		return false
+	}
+
+	code := rerr.Response.StatusCode
+	return code == http.StatusNotFound || code >= http.StatusInternalServerError
+}
+
+// parallel calls fn for every index below n, running at most
+// Client.Concurrency calls at once, and waits for them to finish
+func (c *Client) parallel(n int, fn func(i int)) {
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = defaultConcurrency
+	}
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// ComponentErrors maps component ids to the error returned for each of them
+type ComponentErrors map[string]error
+
+func (e ComponentErrors) Error() string {
+	return formatErrors("components", e)
+}
+
+// PageErrors maps page ids to the error returned for each of them
+type PageErrors map[string]error
+
+func (e PageErrors) Error() string {
+	return formatErrors("pages", e)
+}
+
+func formatErrors(kind string, errs map[string]error) string {
+	ids := make([]string, 0, len(errs))
+	for id := range errs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = id + ": " + errs[id].Error()
+	}
+
+	return fmt.Sprintf("%d %s failed: %s", len(ids), kind, strings.Join(msgs, "; "))
+}
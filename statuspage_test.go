@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -206,3 +207,90 @@ func TestIntegration(t *testing.T) {
 		t.Logf("Components: %d", len(components))
 	}
 }
+
+func TestClient_GetComponentsFromGroup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/component-groups/g", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"g","components":["b","a","c"]}`)
+	})
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"a","group_id":"g"},{"id":"b","group_id":"g"},{"id":"x"}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/components/c", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	})
+
+	components, err := client.GetComponentsFromGroup(context.Background(), "1", "g")
+	if err == nil {
+		t.Fatal("Client.GetComponentsFromGroup expected error for missing component c")
+	}
+
+	failed, ok := err.(statuspage.ComponentErrors)
+	if !ok || len(failed) != 1 || failed["c"] == nil {
+		t.Errorf("Client.GetComponentsFromGroup error = %v, want ComponentErrors for c", err)
+	}
+
+	want := []statuspage.Component{
+		{ID: "b", GroupID: "g"},
+		{ID: "a", GroupID: "g"},
+	}
+	if !reflect.DeepEqual(components, want) {
+		t.Errorf("Client.GetComponentsFromGroup returned %+v, want %+v", components, want)
+	}
+}
+
+func TestClient_GetComponentsFromGroup_fallback(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.Concurrency = 2
+
+	mux.HandleFunc("/v1/pages/1/component-groups/g", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"g","components":["a","b","c"]}`)
+	})
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	for _, id := range []string{"a", "b", "c"} {
+		id := id
+		mux.HandleFunc("/v1/pages/1/components/"+id, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"id":%q}`, id)
+		})
+	}
+
+	components, err := client.GetComponentsFromGroup(context.Background(), "1", "g")
+	if err != nil {
+		t.Fatalf("Client.GetComponentsFromGroup returned error: %v", err)
+	}
+
+	want := []statuspage.Component{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	if !reflect.DeepEqual(components, want) {
+		t.Errorf("Client.GetComponentsFromGroup returned %+v, want %+v", components, want)
+	}
+}
+
+func TestClient_GetComponentsFromGroup_listError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/component-groups/g", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"g","components":["a","b"]}`)
+	})
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/v1/pages/1/components/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	_, err := client.GetComponentsFromGroup(context.Background(), "1", "g")
+	var rerr *statuspage.ErrorResponse
+	if !errors.As(err, &rerr) || rerr.Response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Client.GetComponentsFromGroup error = %v, want the 401 from ListComponents", err)
+	}
+}
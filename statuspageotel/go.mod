@@ -0,0 +1,19 @@
+module github.com/isaaclimdc/statuspage-go/statuspageotel
+
+go 1.18
+
+require (
+	github.com/isaaclimdc/statuspage-go v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/sdk v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/isaaclimdc/statuspage-go => ../
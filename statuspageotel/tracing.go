@@ -0,0 +1,163 @@
+// Package statuspageotel traces the calls a statuspage.Client makes with
+// OpenTelemetry. Tracing is off unless a Tracer is installed on the client:
+//
+//	client.Instrumentation = statuspageotel.New(statuspageotel.WithTracerProvider(tp))
+//
+// Every client method call gets a client span named after the method, such
+// as "statuspage.IncidentService.ModifyIncident". Methods that call other
+// methods get their spans as children, and every request the method sends
+// is recorded as an event on its span and carries the trace context to the
+// API in its headers.
+package statuspageotel
+
+import (
+	"context"
+	"net/http"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/isaaclimdc/statuspage-go/statuspageotel"
+
+// Attribute keys recorded on spans
+const (
+	PageIDKey       = attribute.Key("statuspage.page_id")
+	ResourceKey     = attribute.Key("statuspage.resource")
+	ResourceIDKey   = attribute.Key("statuspage.resource_id")
+	ComponentIDsKey = attribute.Key("statuspage.component_ids")
+	AttemptKey      = attribute.Key("statuspage.attempt")
+)
+
+// Option configures a Tracer
+type Option func(*Tracer)
+
+// WithTracerProvider sets the provider spans are created with. Defaults to
+// the global provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.provider = tp
+	}
+}
+
+// WithPropagator sets how trace context is written to request headers.
+// Defaults to the global propagator.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = p
+	}
+}
+
+// Tracer is a statuspage.Instrumentation that creates a span per client
+// method call
+type Tracer struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	tracer     trace.Tracer
+}
+
+// New returns a Tracer configured by opts
+func New(opts ...Option) *Tracer {
+	t := &Tracer{}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.provider == nil {
+		t.provider = otel.GetTracerProvider()
+	}
+	if t.propagator == nil {
+		t.propagator = otel.GetTextMapPropagator()
+	}
+	t.tracer = t.provider.Tracer(instrumentationName)
+
+	return t
+}
+
+// StartOperation implements statuspage.OperationInstrumentation
+func (t *Tracer) StartOperation(ctx context.Context, info *statuspage.OperationInfo) (context.Context, func(error)) {
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if info.PageID != "" {
+		attrs = append(attrs, PageIDKey.String(info.PageID))
+	}
+	if len(info.ComponentIDs) > 0 {
+		attrs = append(attrs, ComponentIDsKey.StringSlice(info.ComponentIDs))
+	}
+
+	ctx, span := t.tracer.Start(ctx, "statuspage."+info.Service+"."+info.Operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartRequest implements statuspage.Instrumentation. The request is
+// recorded on the span of the method that sent it; a request sent outside
+// of any method gets a span of its own.
+func (t *Tracer) StartRequest(ctx context.Context, info *statuspage.RequestInfo) (context.Context, func(*statuspage.ResponseInfo)) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", info.Method),
+		ResourceKey.String(info.Resource),
+	}
+	if info.ResourceID != "" {
+		attrs = append(attrs, ResourceIDKey.String(info.ResourceID))
+	}
+	if info.Attempt > 1 {
+		attrs = append(attrs, AttemptKey.Int(info.Attempt))
+	}
+
+	if info.Service == "" {
+		return t.startRequestSpan(ctx, info, attrs)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(info.Header))
+
+	return ctx, func(resp *statuspage.ResponseInfo) {
+		if resp.StatusCode != 0 {
+			attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		}
+		if resp.Err != nil {
+			attrs = append(attrs, attribute.String("error.message", resp.Err.Error()))
+		}
+		span.AddEvent("statuspage.request", trace.WithAttributes(attrs...))
+	}
+}
+
+// startRequestSpan traces a request that is not part of a client method
+func (t *Tracer) startRequestSpan(ctx context.Context, info *statuspage.RequestInfo, attrs []attribute.KeyValue) (context.Context, func(*statuspage.ResponseInfo)) {
+	if info.PageID != "" {
+		attrs = append(attrs, PageIDKey.String(info.PageID))
+	}
+
+	ctx, span := t.tracer.Start(ctx, "statuspage."+info.Method+" "+info.Resource,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(info.Header))
+
+	return ctx, func(resp *statuspage.ResponseInfo) {
+		if resp.StatusCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		}
+		if resp.Err != nil {
+			span.RecordError(resp.Err)
+			span.SetStatus(codes.Error, resp.Err.Error())
+		} else if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+		span.End()
+	}
+}
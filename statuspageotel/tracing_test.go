@@ -0,0 +1,146 @@
+package statuspageotel_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+	"github.com/isaaclimdc/statuspage-go/statuspageotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc) (*statuspage.Client, *tracetest.InMemoryExporter) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client := statuspage.NewClient("token", nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	client.Instrumentation = statuspageotel.New(
+		statuspageotel.WithTracerProvider(tp),
+		statuspageotel.WithPropagator(propagation.TraceContext{}),
+	)
+
+	return client, exporter
+}
+
+func TestTracer(t *testing.T) {
+	var traceparent string
+	client, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			traceparent = r.Header.Get("Traceparent")
+			fmt.Fprint(w, `{"id":"i1"}`)
+			return
+		}
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	})
+
+	b := statuspage.NewIncidentBuilder("API errors").Component("api", statuspage.StatusMajorOutage)
+	if _, err := client.Incident.CreateIncidentWith(context.Background(), "p1", b); err != nil {
+		t.Fatalf("IncidentService.CreateIncidentWith returned error: %v", err)
+	}
+	if _, err := client.Component.GetComponent(context.Background(), "p1", "web"); err == nil {
+		t.Fatal("ComponentService.GetComponent expected error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+
+	created := spans[0]
+	if created.Name != "statuspage.IncidentService.CreateIncident" {
+		t.Errorf("span name = %q", created.Name)
+	}
+	if traceparent == "" || traceparent[3:35] != created.SpanContext.TraceID().String() {
+		t.Errorf("traceparent header = %q, want trace %s", traceparent, created.SpanContext.TraceID())
+	}
+	checkAttributes(t, created.Attributes, map[attribute.Key]string{
+		statuspageotel.PageIDKey:       "p1",
+		statuspageotel.ComponentIDsKey: "[api]",
+		"http.response.status_code":    "200",
+	})
+	if len(created.Events) != 1 || created.Events[0].Name != "statuspage.request" {
+		t.Fatalf("span events = %+v, want one request", created.Events)
+	}
+	checkAttributes(t, created.Events[0].Attributes, map[attribute.Key]string{
+		"http.request.method":       "POST",
+		statuspageotel.ResourceKey:  "incidents",
+		"http.response.status_code": "200",
+	})
+
+	failed := spans[1]
+	if failed.Name != "statuspage.ComponentService.GetComponent" || failed.Status.Code != codes.Error {
+		t.Errorf("span = %q with status %v, want failed GetComponent", failed.Name, failed.Status)
+	}
+	checkAttributes(t, failed.Attributes, map[attribute.Key]string{
+		statuspageotel.ComponentIDsKey: "[web]",
+		"http.response.status_code":    "404",
+	})
+}
+
+func TestTracer_nested(t *testing.T) {
+	client, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Errorf("%s %s sent without trace context", r.Method, r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"i1","status":"investigating","updated_at":"2006-01-02T15:04:05Z"}`)
+		case "PATCH":
+			fmt.Fprint(w, `{"id":"i1","status":"identified"}`)
+		}
+	})
+
+	_, err := client.Incident.ModifyIncident(context.Background(), "p1", "i1", func(i *statuspage.Incident) error {
+		i.Status = statuspage.StatusIdentified
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IncidentService.ModifyIncident returned error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = strings.TrimPrefix(span.Name, "statuspage.IncidentService.")
+	}
+	if got, want := strings.Join(names, ","), "GetIncident,GetIncident,PatchIncident,ModifyIncident"; got != want {
+		t.Fatalf("spans = %s, want %s", got, want)
+	}
+
+	parent := spans[3]
+	for _, child := range spans[:3] {
+		if child.Parent.SpanID() != parent.SpanContext.SpanID() {
+			t.Errorf("span %s is not a child of ModifyIncident", child.Name)
+		}
+	}
+	if len(parent.Events) != 0 {
+		t.Errorf("ModifyIncident span has %d request events, want them on its children", len(parent.Events))
+	}
+}
+
+func checkAttributes(t *testing.T, attrs []attribute.KeyValue, want map[attribute.Key]string) {
+	t.Helper()
+
+	got := make(map[attribute.Key]string, len(attrs))
+	for _, kv := range attrs {
+		got[kv.Key] = kv.Value.Emit()
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("attribute %s = %q, want %q", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package statuspageprom exports Prometheus metrics about the requests a
+// statuspage.Client sends.
+//
+//	metrics, err := statuspageprom.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		return err
+//	}
+//	client.Instrumentation = metrics
+package statuspageprom
+
+import (
+	"context"
+	"strconv"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is a statuspage.Instrumentation that records Prometheus metrics.
+// Requests are labelled with the client service and method that sent them,
+// e.g. service="IncidentService" and method="CreateIncident".
+type Metrics struct {
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	retries   *prometheus.CounterVec
+	rateLimit prometheus.Gauge
+}
+
+// New creates the metrics and registers them with reg
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "statuspage",
+			Name:      "requests_total",
+			Help:      "Requests sent to the Statuspage API, by client service, method and HTTP status code.",
+		}, []string{"service", "method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "statuspage",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests to the Statuspage API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "method"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "statuspage",
+			Name:      "request_retries_total",
+			Help:      "Requests to the Statuspage API that repeated an earlier attempt.",
+		}, []string{"service", "method"}),
+		rateLimit: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "statuspage",
+			Name:      "rate_limit_remaining",
+			Help:      "Requests left in the current Statuspage API rate limit window.",
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.retries, m.rateLimit} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// StartRequest implements statuspage.Instrumentation
+func (m *Metrics) StartRequest(ctx context.Context, info *statuspage.RequestInfo) (context.Context, func(*statuspage.ResponseInfo)) {
+	if info.Attempt > 1 {
+		m.retries.WithLabelValues(info.Service, info.Operation).Inc()
+	}
+
+	return ctx, func(resp *statuspage.ResponseInfo) {
+		code := "error"
+		if resp.StatusCode != 0 {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+
+		m.requests.WithLabelValues(info.Service, info.Operation, code).Inc()
+		m.duration.WithLabelValues(info.Service, info.Operation).Observe(resp.Duration.Seconds())
+		if resp.RateLimitRemaining >= 0 {
+			m.rateLimit.Set(float64(resp.RateLimitRemaining))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package statuspageprom_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+	"github.com/isaaclimdc/statuspage-go/statuspageprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/pages/1/incidents/missing" {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "57")
+		fmt.Fprint(w, `{"id":"i1"}`)
+	}))
+	defer server.Close()
+
+	client := statuspage.NewClient("token", nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	reg := prometheus.NewRegistry()
+	metrics, err := statuspageprom.New(reg)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	client.Instrumentation = metrics
+
+	ctx := context.Background()
+	client.Incident.GetIncident(ctx, "1", "i1")
+	client.Incident.GetIncident(statuspage.ContextWithAttempt(ctx, 2), "1", "i1")
+	client.Incident.GetIncident(ctx, "1", "missing")
+
+	want := `
+# HELP statuspage_rate_limit_remaining Requests left in the current Statuspage API rate limit window.
+# TYPE statuspage_rate_limit_remaining gauge
+statuspage_rate_limit_remaining 57
+# HELP statuspage_request_retries_total Requests to the Statuspage API that repeated an earlier attempt.
+# TYPE statuspage_request_retries_total counter
+statuspage_request_retries_total{method="GetIncident",service="IncidentService"} 1
+# HELP statuspage_requests_total Requests sent to the Statuspage API, by client service, method and HTTP status code.
+# TYPE statuspage_requests_total counter
+statuspage_requests_total{code="200",method="GetIncident",service="IncidentService"} 2
+statuspage_requests_total{code="404",method="GetIncident",service="IncidentService"} 1
+`
+	err = testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"statuspage_requests_total", "statuspage_request_retries_total", "statuspage_rate_limit_remaining")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if n := testutil.CollectAndCount(reg, "statuspage_request_duration_seconds"); n != 1 {
+		t.Errorf("statuspage_request_duration_seconds has %d series, want 1", n)
+	}
+}
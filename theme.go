@@ -0,0 +1,207 @@
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// PageTheme is the set of colors that brand a status page. Empty fields are
+// left untouched when the theme is applied.
+type PageTheme struct {
+	BodyBackgroundColor string `json:"body_background_color,omitempty" yaml:"body_background_color,omitempty"`
+	FontColor           string `json:"font_color,omitempty" yaml:"font_color,omitempty"`
+	LightFontColor      string `json:"light_font_color,omitempty" yaml:"light_font_color,omitempty"`
+	Greens              string `json:"greens,omitempty" yaml:"greens,omitempty"`
+	Yellows             string `json:"yellows,omitempty" yaml:"yellows,omitempty"`
+	Oranges             string `json:"oranges,omitempty" yaml:"oranges,omitempty"`
+	Reds                string `json:"reds,omitempty" yaml:"reds,omitempty"`
+	Blues               string `json:"blues,omitempty" yaml:"blues,omitempty"`
+	BorderColor         string `json:"border_color,omitempty" yaml:"border_color,omitempty"`
+	GraphColor          string `json:"graph_color,omitempty" yaml:"graph_color,omitempty"`
+	LinkColor           string `json:"link_color,omitempty" yaml:"link_color,omitempty"`
+}
+
+// ThemeChange is a single color that differs between a page and a theme
+type ThemeChange struct {
+	Field   string
+	Current string
+	Desired string
+}
+
+func (c ThemeChange) String() string {
+	return fmt.Sprintf("%s: %q -> %q", c.Field, c.Current, c.Desired)
+}
+
+// themeField ties a theme color to the matching Page and UpdatePageParams fields
+type themeField struct {
+	name    string
+	desired string
+	current func(p *Page) *string
+	set     func(p *UpdatePageParams, v string)
+}
+
+func (t *PageTheme) fields() []themeField {
+	return []themeField{
+		{"body_background_color", t.BodyBackgroundColor, func(p *Page) *string { return p.CSSBodyBackgroundColor }, func(p *UpdatePageParams, v string) { p.CSSBodyBackgroundColor = Value(v) }},
+		{"font_color", t.FontColor, func(p *Page) *string { return p.CSSFontColor }, func(p *UpdatePageParams, v string) { p.CSSFontColor = Value(v) }},
+		{"light_font_color", t.LightFontColor, func(p *Page) *string { return p.CSSLightFontColor }, func(p *UpdatePageParams, v string) { p.CSSLightFontColor = Value(v) }},
+		{"greens", t.Greens, func(p *Page) *string { return p.CSSGreens }, func(p *UpdatePageParams, v string) { p.CSSGreens = Value(v) }},
+		{"yellows", t.Yellows, func(p *Page) *string { return p.CSSYellows }, func(p *UpdatePageParams, v string) { p.CSSYellows = Value(v) }},
+		{"oranges", t.Oranges, func(p *Page) *string { return p.CSSOranges }, func(p *UpdatePageParams, v string) { p.CSSOranges = Value(v) }},
+		{"reds", t.Reds, func(p *Page) *string { return p.CSSReds }, func(p *UpdatePageParams, v string) { p.CSSReds = Value(v) }},
+		{"blues", t.Blues, func(p *Page) *string { return p.CSSBlues }, func(p *UpdatePageParams, v string) { p.CSSBlues = Value(v) }},
+		{"border_color", t.BorderColor, func(p *Page) *string { return p.CSSBorderColor }, func(p *UpdatePageParams, v string) { p.CSSBorderColor = Value(v) }},
+		{"graph_color", t.GraphColor, func(p *Page) *string { return p.CSSGraphColor }, func(p *UpdatePageParams, v string) { p.CSSGraphColor = Value(v) }},
+		{"link_color", t.LinkColor, func(p *Page) *string { return p.CSSLinkColor }, func(p *UpdatePageParams, v string) { p.CSSLinkColor = Value(v) }},
+	}
+}
+
+// ParsePageTheme decodes and validates a theme. Both YAML and JSON are
+// accepted, as JSON is valid YAML.
+func ParsePageTheme(data []byte) (*PageTheme, error) {
+	var theme PageTheme
+	if err := yaml.Unmarshal(data, &theme); err != nil {
+		return nil, fmt.Errorf("error decoding page theme: %s", err)
+	}
+
+	if err := theme.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &theme, nil
+}
+
+// LoadPageTheme reads, decodes and validates a theme file
+func LoadPageTheme(path string) (*PageTheme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePageTheme(data)
+}
+
+// Validate checks that every color set in the theme is a #rgb or #rrggbb hex color
+func (t *PageTheme) Validate() error {
+	invalid := make([]string, 0)
+	for _, f := range t.fields() {
+		if f.desired != "" && !hexColorPattern.MatchString(f.desired) {
+			invalid = append(invalid, fmt.Sprintf("%s %q", f.name, f.desired))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid page theme colors: %s", strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+// Diff returns the colors of page that differ from the theme. Colors are
+// compared case-insensitively, with #rgb expanded to #rrggbb.
+func (t *PageTheme) Diff(page *Page) []ThemeChange {
+	changes := make([]ThemeChange, 0)
+	for _, f := range t.fields() {
+		if f.desired == "" {
+			continue
+		}
+
+		current := ""
+		if v := f.current(page); v != nil {
+			current = *v
+		}
+
+		if normalizeHexColor(current) != normalizeHexColor(f.desired) {
+			changes = append(changes, ThemeChange{Field: f.name, Current: current, Desired: f.desired})
+		}
+	}
+
+	return changes
+}
+
+// Params returns the update page parameters that set every color in the theme
+func (t *PageTheme) Params() UpdatePageParams {
+	var params UpdatePageParams
+	for _, f := range t.fields() {
+		if f.desired != "" {
+			f.set(&params, f.desired)
+		}
+	}
+	return params
+}
+
+func normalizeHexColor(color string) string {
+	color = strings.ToLower(color)
+	if len(color) == 4 && color[0] == '#' {
+		return string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+	}
+	return color
+}
+
+// DiffPageTheme returns the colors of a given page id that differ from the theme
+func (c *Client) DiffPageTheme(ctx context.Context, pageID string, theme *PageTheme) (_ []ThemeChange, err error) {
+	ctx, end := c.startOperation(ctx, "Client.DiffPageTheme", pageID)
+	defer func() { end(err) }()
+
+	page, err := c.Page.GetPage(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return theme.Diff(page), nil
+}
+
+// ApplyPageTheme brings each of the given pages in line with the theme. Only
+// the colors that differ are sent, and pages that already match are not
+// updated. The changes made are returned per page id; pages that could not
+// be read or updated are reported in a PageErrors.
+func (c *Client) ApplyPageTheme(ctx context.Context, theme *PageTheme, pageIDs ...string) (_ map[string][]ThemeChange, err error) {
+	ctx, end := c.startOperation(ctx, "Client.ApplyPageTheme", "")
+	defer func() { end(err) }()
+
+	if err := theme.Validate(); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string][]ThemeChange, len(pageIDs))
+	failed := make(PageErrors)
+
+	for _, pageID := range pageIDs {
+		changes, err := c.DiffPageTheme(ctx, pageID, theme)
+		if err != nil {
+			failed[pageID] = err
+			continue
+		}
+
+		if len(changes) > 0 {
+			var params UpdatePageParams
+			for _, f := range theme.fields() {
+				for _, change := range changes {
+					if change.Field == f.name {
+						f.set(&params, f.desired)
+					}
+				}
+			}
+
+			if _, err := c.Page.UpdatePage(ctx, pageID, params); err != nil {
+				failed[pageID] = err
+				continue
+			}
+		}
+
+		applied[pageID] = changes
+	}
+
+	if len(failed) > 0 {
+		return applied, failed
+	}
+
+	return applied, nil
+}
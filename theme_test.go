@@ -0,0 +1,87 @@
+package statuspage_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestParsePageTheme(t *testing.T) {
+	yamlTheme := []byte("font_color: \"#333333\"\nlink_color: \"#0af\"\n")
+	jsonTheme := []byte(`{"font_color": "#333333", "link_color": "#0af"}`)
+
+	want := &statuspage.PageTheme{FontColor: "#333333", LinkColor: "#0af"}
+	for _, data := range [][]byte{yamlTheme, jsonTheme} {
+		theme, err := statuspage.ParsePageTheme(data)
+		if err != nil {
+			t.Errorf("ParsePageTheme(%s) returned error: %v", data, err)
+			continue
+		}
+		if !reflect.DeepEqual(theme, want) {
+			t.Errorf("ParsePageTheme(%s) returned %+v, want %+v", data, theme, want)
+		}
+	}
+
+	if _, err := statuspage.ParsePageTheme([]byte(`reds: "red"`)); err == nil {
+		t.Error("ParsePageTheme with a named color expected error")
+	}
+}
+
+func TestPageTheme_Diff(t *testing.T) {
+	theme := &statuspage.PageTheme{FontColor: "#333", LinkColor: "#0000ff", Reds: "#ff0000"}
+	page := &statuspage.Page{
+		CSSFontColor: String("#333333"),
+		CSSLinkColor: String("#00F"),
+	}
+
+	changes := theme.Diff(page)
+	want := []statuspage.ThemeChange{
+		{Field: "reds", Current: "", Desired: "#ff0000"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("PageTheme.Diff returned %+v, want %+v", changes, want)
+	}
+}
+
+func TestClient_ApplyPageTheme(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"id":"1","css_font_color":"#000000","css_reds":"#ff0000"}`)
+		case "PATCH":
+			v := &statuspage.UpdatePageRequestBody{}
+			json.NewDecoder(r.Body).Decode(v)
+			want := statuspage.UpdatePageParams{CSSFontColor: statuspage.Value("#333333")}
+			if !reflect.DeepEqual(v.Page, want) {
+				t.Errorf("Request body = %+v, want %+v", v.Page, want)
+			}
+			fmt.Fprint(w, `{"id":"1"}`)
+		}
+	})
+	mux.HandleFunc("/v1/pages/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"2","css_font_color":"#333333","css_reds":"#FF0000"}`)
+	})
+
+	theme := &statuspage.PageTheme{FontColor: "#333333", Reds: "#ff0000"}
+	applied, err := client.ApplyPageTheme(context.Background(), theme, "1", "2")
+	if err != nil {
+		t.Fatalf("Client.ApplyPageTheme returned error: %v", err)
+	}
+
+	want := map[string][]statuspage.ThemeChange{
+		"1": {{Field: "font_color", Current: "#000000", Desired: "#333333"}},
+		"2": {},
+	}
+	if !reflect.DeepEqual(applied, want) {
+		t.Errorf("Client.ApplyPageTheme returned %+v, want %+v", applied, want)
+	}
+}
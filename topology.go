@@ -0,0 +1,263 @@
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PageTopology is the structure of a page as it is displayed: its groups in
+// order, the components inside each group in order, and the components
+// that do not belong to any group. Groups and ungrouped components share
+// one top-level order on the page; Entries returns them interleaved.
+type PageTopology struct {
+	PageID    string
+	Groups    []TopologyGroup
+	Ungrouped []Component
+}
+
+// TopologyGroup is a component group together with its components
+type TopologyGroup struct {
+	Group Group
+	// Component is the group's own entry in the component list
+	// (Component.Group == true), if the API returned one.
+	Component  *Component
+	Components []Component
+}
+
+// TopologyEntry is a top-level entry of a page: either a group or a
+// component that does not belong to any group
+type TopologyEntry struct {
+	Group     *TopologyGroup
+	Component *Component
+}
+
+// Status returns the status of the group's own component entry, if any
+func (g TopologyGroup) Status() string {
+	if g.Component == nil {
+		return ""
+	}
+	return g.Component.Status
+}
+
+// GetPageTopology fetches the groups and components of a page and arranges them
+// into a PageTopology
+func (c *Client) GetPageTopology(ctx context.Context, pageID string) (_ *PageTopology, err error) {
+	ctx, end := c.startOperation(ctx, "Client.GetPageTopology", pageID)
+	defer func() { end(err) }()
+
+	groups, err := c.Group.GetGroups(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := c.Component.ListComponents(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPageTopology(pageID, groups, components), nil
+}
+
+// NewPageTopology arranges groups and components into a PageTopology. Group
+// entries in components are matched to their group by id, and a group that is
+// only known from its component entry is still included. Components whose
+// group is unknown are treated as ungrouped.
+func NewPageTopology(pageID string, groups []Group, components []Component) *PageTopology {
+	t := &PageTopology{PageID: pageID}
+
+	index := make(map[string]int, len(groups))
+	for _, g := range groups {
+		g.FullComponents = nil
+		index[g.ID] = len(t.Groups)
+		t.Groups = append(t.Groups, TopologyGroup{Group: g})
+	}
+
+	for _, comp := range components {
+		if !comp.Group {
+			continue
+		}
+		comp := comp
+		i, ok := index[comp.ID]
+		if !ok {
+			i = len(t.Groups)
+			index[comp.ID] = i
+			t.Groups = append(t.Groups, TopologyGroup{Group: Group{
+				ID:          comp.ID,
+				PageID:      comp.PageID,
+				CreatedAt:   comp.CreatedAt,
+				UpdatedAt:   comp.UpdatedAt,
+				Name:        comp.Name,
+				Description: comp.Description,
+				Position:    comp.Position,
+			}})
+		}
+		t.Groups[i].Component = &comp
+	}
+
+	for _, comp := range components {
+		if comp.Group {
+			continue
+		}
+		if i, ok := index[comp.GroupID]; ok && comp.GroupID != "" {
+			t.Groups[i].Components = append(t.Groups[i].Components, comp)
+			continue
+		}
+		t.Ungrouped = append(t.Ungrouped, comp)
+	}
+
+	sort.SliceStable(t.Groups, func(i, j int) bool {
+		return t.Groups[i].Group.Position < t.Groups[j].Group.Position
+	})
+	for _, g := range t.Groups {
+		sortComponents(g.Components)
+	}
+	sortComponents(t.Ungrouped)
+
+	return t
+}
+
+func sortComponents(components []Component) {
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].Position < components[j].Position
+	})
+}
+
+// Entries returns the groups and ungrouped components of the page in
+// display order, merged by position
+func (t *PageTopology) Entries() []TopologyEntry {
+	entries := make([]TopologyEntry, 0, len(t.Groups)+len(t.Ungrouped))
+	i, j := 0, 0
+	for i < len(t.Groups) || j < len(t.Ungrouped) {
+		if j == len(t.Ungrouped) || (i < len(t.Groups) && t.Groups[i].Group.Position <= t.Ungrouped[j].Position) {
+			entries = append(entries, TopologyEntry{Group: &t.Groups[i]})
+			i++
+			continue
+		}
+		entries = append(entries, TopologyEntry{Component: &t.Ungrouped[j]})
+		j++
+	}
+	return entries
+}
+
+// Components returns every component on the page, excluding group entries,
+// in display order
+func (t *PageTopology) Components() []Component {
+	components := make([]Component, 0)
+	for _, e := range t.Entries() {
+		if e.Group != nil {
+			components = append(components, e.Group.Components...)
+			continue
+		}
+		components = append(components, *e.Component)
+	}
+	return components
+}
+
+// Component returns the component with the given id, including group entries
+func (t *PageTopology) Component(id string) (*Component, bool) {
+	return t.findComponent(func(c *Component) bool { return c.ID == id })
+}
+
+// ComponentByName returns the first component with the given name, compared
+// case-insensitively
+func (t *PageTopology) ComponentByName(name string) (*Component, bool) {
+	return t.findComponent(func(c *Component) bool { return !c.Group && strings.EqualFold(c.Name, name) })
+}
+
+func (t *PageTopology) findComponent(match func(c *Component) bool) (*Component, bool) {
+	for i := range t.Groups {
+		g := &t.Groups[i]
+		if g.Component != nil && match(g.Component) {
+			return g.Component, true
+		}
+		for j := range g.Components {
+			if match(&g.Components[j]) {
+				return &g.Components[j], true
+			}
+		}
+	}
+	for i := range t.Ungrouped {
+		if match(&t.Ungrouped[i]) {
+			return &t.Ungrouped[i], true
+		}
+	}
+	return nil, false
+}
+
+// Group returns the group with the given id
+func (t *PageTopology) Group(id string) (*TopologyGroup, bool) {
+	for i := range t.Groups {
+		if t.Groups[i].Group.ID == id {
+			return &t.Groups[i], true
+		}
+	}
+	return nil, false
+}
+
+// GroupByName returns the first group with the given name, compared
+// case-insensitively
+func (t *PageTopology) GroupByName(name string) (*TopologyGroup, bool) {
+	for i := range t.Groups {
+		if strings.EqualFold(t.Groups[i].Group.Name, name) {
+			return &t.Groups[i], true
+		}
+	}
+	return nil, false
+}
+
+// GroupOf returns the group that contains the component with the given id
+func (t *PageTopology) GroupOf(componentID string) (*TopologyGroup, bool) {
+	for i := range t.Groups {
+		for _, c := range t.Groups[i].Components {
+			if c.ID == componentID {
+				return &t.Groups[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// WriteTree writes the topology to w as an indented tree, one line per group
+// or component with its status
+func (t *PageTopology) WriteTree(w io.Writer) error {
+	for _, e := range t.Entries() {
+		if e.Component != nil {
+			if _, err := fmt.Fprintln(w, treeLine(e.Component.Name, e.Component.Status)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		g := e.Group
+		if _, err := fmt.Fprintln(w, treeLine(g.Group.Name, g.Status())); err != nil {
+			return err
+		}
+		for i, c := range g.Components {
+			branch := "├── "
+			if i == len(g.Components)-1 {
+				branch = "└── "
+			}
+			if _, err := fmt.Fprintln(w, branch+treeLine(c.Name, c.Status)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func treeLine(name, status string) string {
+	if status == "" {
+		return name
+	}
+	return name + " [" + status + "]"
+}
+
+func (t PageTopology) String() string {
+	var buf bytes.Buffer
+	t.WriteTree(&buf)
+	return buf.String()
+}
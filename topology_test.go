@@ -0,0 +1,140 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestNewPageTopology(t *testing.T) {
+	groups := []statuspage.Group{
+		{ID: "g2", Name: "Backend", Position: 2},
+		{ID: "g1", Name: "Frontend", Position: 1},
+	}
+	components := []statuspage.Component{
+		{ID: "g1", Name: "Frontend", Group: true, Status: statuspage.StatusOperational},
+		{ID: "g3", Name: "Data", Group: true, Position: 3},
+		{ID: "api", Name: "API", GroupID: "g2", Position: 2},
+		{ID: "db", Name: "Database", GroupID: "g2", Position: 1},
+		{ID: "web", Name: "Web", GroupID: "g1", Status: statuspage.StatusDegraded},
+		{ID: "dns", Name: "DNS", Position: 4},
+		{ID: "orphan", Name: "Orphan", GroupID: "gone", Position: 5},
+	}
+
+	topology := statuspage.NewPageTopology("1", groups, components)
+
+	var names []string
+	for _, g := range topology.Groups {
+		names = append(names, g.Group.Name)
+	}
+	if want := []string{"Frontend", "Backend", "Data"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NewPageTopology group order = %v, want %v", names, want)
+	}
+
+	backend, ok := topology.Group("g2")
+	if !ok || len(backend.Components) != 2 || backend.Components[0].ID != "db" {
+		t.Errorf("NewPageTopology backend group = %+v, want db before api", backend)
+	}
+	if got := topology.Groups[0].Status(); got != statuspage.StatusOperational {
+		t.Errorf("TopologyGroup.Status = %q, want %q", got, statuspage.StatusOperational)
+	}
+
+	var ungrouped []string
+	for _, c := range topology.Ungrouped {
+		ungrouped = append(ungrouped, c.ID)
+	}
+	if want := []string{"dns", "orphan"}; !reflect.DeepEqual(ungrouped, want) {
+		t.Errorf("NewPageTopology ungrouped = %v, want %v", ungrouped, want)
+	}
+
+	if c, ok := topology.ComponentByName("api"); !ok || c.ID != "api" {
+		t.Errorf("PageTopology.ComponentByName(api) = %+v, %v", c, ok)
+	}
+	if g, ok := topology.GroupOf("web"); !ok || g.Group.ID != "g1" {
+		t.Errorf("PageTopology.GroupOf(web) = %+v, %v", g, ok)
+	}
+
+	want := "Frontend [operational]\n" +
+		"└── Web [degraded_performance]\n" +
+		"Backend\n" +
+		"├── Database\n" +
+		"└── API\n" +
+		"Data\n" +
+		"DNS\n" +
+		"Orphan\n"
+	if got := topology.String(); got != want {
+		t.Errorf("PageTopology.String returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPageTopology_Entries(t *testing.T) {
+	groups := []statuspage.Group{
+		{ID: "g1", Name: "Frontend", Position: 1},
+		{ID: "g2", Name: "Backend", Position: 3},
+	}
+	components := []statuspage.Component{
+		{ID: "web", Name: "Web", GroupID: "g1"},
+		{ID: "db", Name: "Database", GroupID: "g2"},
+		{ID: "cdn", Name: "CDN", Position: 2},
+		{ID: "dns", Name: "DNS", Position: 4},
+	}
+
+	topology := statuspage.NewPageTopology("1", groups, components)
+
+	var entries []string
+	for _, e := range topology.Entries() {
+		if e.Group != nil {
+			entries = append(entries, e.Group.Group.ID)
+			continue
+		}
+		entries = append(entries, e.Component.ID)
+	}
+	if want := []string{"g1", "cdn", "g2", "dns"}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("PageTopology.Entries = %v, want %v", entries, want)
+	}
+
+	var ids []string
+	for _, c := range topology.Components() {
+		ids = append(ids, c.ID)
+	}
+	if want := []string{"web", "cdn", "db", "dns"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("PageTopology.Components = %v, want %v", ids, want)
+	}
+
+	want := "Frontend\n" +
+		"└── Web\n" +
+		"CDN\n" +
+		"Backend\n" +
+		"└── Database\n" +
+		"DNS\n"
+	if got := topology.String(); got != want {
+		t.Errorf("PageTopology.String returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestClient_GetPageTopology(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/component-groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"g","name":"Group","components":["a"]}]`)
+	})
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"a","group_id":"g"},{"id":"b"}]`)
+	})
+
+	topology, err := client.GetPageTopology(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Client.GetPageTopology returned error: %v", err)
+	}
+
+	if len(topology.Groups) != 1 || len(topology.Groups[0].Components) != 1 || len(topology.Ungrouped) != 1 {
+		t.Errorf("Client.GetPageTopology returned %+v", topology)
+	}
+}
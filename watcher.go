@@ -0,0 +1,222 @@
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultRequestsPerSecond is the Statuspage API rate limit for a token
+	defaultRequestsPerSecond = 1
+
+	// requestsPerPoll is the number of requests a Watcher makes on every
+	// poll, plus one for every further hundred unresolved incidents
+	requestsPerPoll = 2
+
+	maxWatchBackoff = 5 * time.Minute
+)
+
+// Event is a change observed by a Watcher. It is one of
+// ComponentStatusChanged, IncidentCreated, IncidentUpdated,
+// IncidentResolved or WatchError.
+type Event interface {
+	event()
+}
+
+// ComponentStatusChanged is sent when a component changes status. A
+// component that was added to the page has an empty PreviousStatus.
+type ComponentStatusChanged struct {
+	Component      Component
+	PreviousStatus string
+}
+
+// IncidentCreated is sent when a new unresolved incident appears
+type IncidentCreated struct {
+	Incident Incident
+}
+
+// IncidentUpdated is sent when an unresolved incident changes
+type IncidentUpdated struct {
+	Incident Incident
+	Previous Incident
+}
+
+// IncidentResolved is sent when an incident is no longer unresolved
+type IncidentResolved struct {
+	Incident Incident
+}
+
+// WatchError is sent when a poll fails. The Watcher keeps polling.
+type WatchError struct {
+	Err error
+}
+
+func (ComponentStatusChanged) event() {}
+func (IncidentCreated) event()        {}
+func (IncidentUpdated) event()        {}
+func (IncidentResolved) event()       {}
+func (WatchError) event()             {}
+
+// Watcher polls the components and unresolved incidents of a page and
+// reports what changed between polls. The first poll only records the
+// current state.
+type Watcher struct {
+	client *Client
+	pageID string
+
+	// Interval is the time between polls.
+	Interval time.Duration
+
+	// RequestsPerSecond is the share of the token's rate limit the Watcher may
+	// use. Intervals too short for it are lengthened, and polling backs off
+	// while the API answers 429 Too Many Requests. Defaults to the Statuspage
+	// limit of one request per second.
+	RequestsPerSecond float64
+
+	components map[string]Component
+	incidents  map[string]Incident
+}
+
+// NewWatcher returns a Watcher for a given page id polling at interval
+func (c *Client) NewWatcher(pageID string, interval time.Duration) *Watcher {
+	if pageID == "" {
+		pageID = c.defaultPage
+	}
+
+	return &Watcher{client: c, pageID: pageID, Interval: interval}
+}
+
+// Watch starts polling and returns the channel events are sent on. The
+// channel is closed once ctx is done.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		backoff := time.Duration(0)
+		for {
+			throttled := false
+			for _, e := range w.poll(ctx) {
+				if werr, ok := e.(WatchError); ok && isRateLimited(werr.Err) {
+					throttled = true
+				}
+
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			switch {
+			case !throttled:
+				backoff = 0
+			case backoff == 0:
+				backoff = w.interval()
+			default:
+				backoff *= 2
+				if backoff > maxWatchBackoff {
+					backoff = maxWatchBackoff
+				}
+			}
+
+			timer := time.NewTimer(w.interval() + backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// interval returns the poll interval, lengthened to stay within the rate limit
+func (w *Watcher) interval() time.Duration {
+	rps := w.RequestsPerSecond
+	if rps <= 0 {
+		rps = defaultRequestsPerSecond
+	}
+
+	shortest := time.Duration(float64(requestsPerPoll) / rps * float64(time.Second))
+	if w.Interval < shortest {
+		return shortest
+	}
+	return w.Interval
+}
+
+// poll fetches the page state and returns the events since the last poll.
+// Each poll is reported as an operation of Watch.
+func (w *Watcher) poll(ctx context.Context) []Event {
+	ctx, end := w.client.startOperation(ctx, "Watcher.Watch", w.pageID)
+
+	components, err := w.client.Component.ListComponents(ctx, w.pageID)
+	if err != nil {
+		return []Event{WatchError{Err: end(err)}}
+	}
+
+	unresolved, err := w.client.Incident.listAllUnresolvedIncidents(ctx, w.pageID)
+	if err != nil {
+		return []Event{WatchError{Err: end(err)}}
+	}
+	end(nil)
+
+	first := w.components == nil
+	events := make([]Event, 0)
+
+	current := make(map[string]Component, len(components))
+	for _, c := range components {
+		if c.Group {
+			continue
+		}
+		current[c.ID] = c
+
+		previous, ok := w.components[c.ID]
+		if !first && (!ok || previous.Status != c.Status) {
+			events = append(events, ComponentStatusChanged{Component: c, PreviousStatus: previous.Status})
+		}
+	}
+
+	open := make(map[string]Incident, len(unresolved))
+	for _, incident := range unresolved {
+		open[incident.ID] = incident
+
+		previous, ok := w.incidents[incident.ID]
+		switch {
+		case first:
+		case !ok:
+			events = append(events, IncidentCreated{Incident: incident})
+		case !incident.UpdatedAt.Equal(previous.UpdatedAt):
+			events = append(events, IncidentUpdated{Incident: incident, Previous: previous})
+		}
+	}
+
+	for id, previous := range w.incidents {
+		if _, ok := open[id]; ok {
+			continue
+		}
+
+		// Fetch the incident for its final state; if it is gone, report the
+		// last state seen.
+		resolved := previous
+		if incident, err := w.client.Incident.GetIncident(ctx, w.pageID, id); err == nil {
+			resolved = *incident
+		}
+		events = append(events, IncidentResolved{Incident: resolved})
+	}
+
+	w.components = current
+	w.incidents = open
+
+	return events
+}
+
+func isRateLimited(err error) bool {
+	var rerr *ErrorResponse
+	return errors.As(err, &rerr) && rerr.Response.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,170 @@
+package statuspage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+func TestWatcher_Watch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	poll := 0
+	next := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		poll++
+		return poll
+	}
+
+	components := []string{
+		`[{"id":"api","status":"operational"},{"id":"web","status":"operational"}]`,
+		`[{"id":"api","status":"major_outage"},{"id":"web","status":"operational"}]`,
+		`[{"id":"api","status":"operational"},{"id":"web","status":"operational"}]`,
+	}
+	incidents := []string{
+		`[{"id":"i1","updated_at":"2006-01-02T15:04:05Z"}]`,
+		`[{"id":"i1","updated_at":"2006-01-02T15:10:00Z"},{"id":"i2","updated_at":"2006-01-02T15:10:00Z"}]`,
+		`[{"id":"i2","updated_at":"2006-01-02T15:10:00Z"}]`,
+	}
+
+	current := 0
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		current = next() - 1
+		if current >= len(components) {
+			current = len(components) - 1
+		}
+		fmt.Fprint(w, components[current])
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, incidents[current])
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/i1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"i1","status":"resolved"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher := client.NewWatcher("1", time.Millisecond)
+	watcher.RequestsPerSecond = 1000
+
+	got := make([]string, 0)
+	for e := range watcher.Watch(ctx) {
+		switch e := e.(type) {
+		case statuspage.ComponentStatusChanged:
+			got = append(got, fmt.Sprintf("component %s %s->%s", e.Component.ID, e.PreviousStatus, e.Component.Status))
+		case statuspage.IncidentCreated:
+			got = append(got, "created "+e.Incident.ID)
+		case statuspage.IncidentUpdated:
+			got = append(got, "updated "+e.Incident.ID)
+		case statuspage.IncidentResolved:
+			got = append(got, "resolved "+e.Incident.ID+" "+e.Incident.Status)
+		case statuspage.WatchError:
+			t.Errorf("Watcher sent error: %v", e.Err)
+		}
+
+		if len(got) == 5 {
+			cancel()
+		}
+	}
+
+	want := []string{
+		"component api operational->major_outage",
+		"updated i1",
+		"created i2",
+		"component api major_outage->operational",
+		"resolved i1 resolved",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Watcher events = %q, want %q", got, want)
+	}
+}
+
+func TestWatcher_Watch_rateLimited(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"rate limited"}`, http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher := client.NewWatcher("1", time.Millisecond)
+	watcher.RequestsPerSecond = 1000
+
+	events := watcher.Watch(ctx)
+	e := <-events
+	if _, ok := e.(statuspage.WatchError); !ok {
+		t.Errorf("Watcher sent %#v, want WatchError", e)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatcher_Watch_paginated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	polls := 0
+	mux.HandleFunc("/v1/pages/1/components", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		mu.Unlock()
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/v1/pages/1/incidents/unresolved", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			incidents := make([]string, 100)
+			for i := range incidents {
+				incidents[i] = fmt.Sprintf(`{"id":"i%d","updated_at":"2006-01-02T15:04:05Z"}`, i)
+			}
+			fmt.Fprint(w, "["+strings.Join(incidents, ",")+"]")
+			return
+		}
+
+		mu.Lock()
+		updated := "15:04:05"
+		if polls > 1 {
+			updated = "15:10:00"
+		}
+		mu.Unlock()
+		fmt.Fprintf(w, `[{"id":"i100","updated_at":"2006-01-02T%sZ"}]`, updated)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher := client.NewWatcher("1", time.Millisecond)
+	watcher.RequestsPerSecond = 1000
+
+	for e := range watcher.Watch(ctx) {
+		switch e := e.(type) {
+		case statuspage.IncidentUpdated:
+			if e.Incident.ID != "i100" {
+				t.Errorf("Watcher sent update for %s, want i100", e.Incident.ID)
+			}
+			cancel()
+		case statuspage.IncidentCreated, statuspage.IncidentResolved:
+			t.Errorf("Watcher sent %#v, want only the update on the second page", e)
+			cancel()
+		case statuspage.WatchError:
+			t.Errorf("Watcher sent error: %v", e.Err)
+		}
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		t.Error("Watcher never saw the update to an incident on the second page")
+	}
+}
@@ -0,0 +1,167 @@
+package statuspage
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Webhook event types, as reported by WebhookEvent.Type
+const (
+	WebhookEventIncident        = "incident"
+	WebhookEventComponentUpdate = "component_update"
+	WebhookEventUnknown         = "unknown"
+)
+
+const maxWebhookBodySize = 1 << 20
+
+// WebhookMeta is the meta block included in every webhook notification
+type WebhookMeta struct {
+	Unsubscribe   string `json:"unsubscribe,omitempty"`
+	Documentation string `json:"documentation,omitempty"`
+}
+
+// WebhookPage is the page block included in every webhook notification
+type WebhookPage struct {
+	ID                string `json:"id,omitempty"`
+	StatusIndicator   string `json:"status_indicator,omitempty"`
+	StatusDescription string `json:"status_description,omitempty"`
+}
+
+// WebhookComponentUpdate describes a component status transition
+type WebhookComponentUpdate struct {
+	ID          string    `json:"id,omitempty"`
+	ComponentID string    `json:"component_id,omitempty"`
+	CreatedAt   Timestamp `json:"created_at,omitempty"`
+	OldStatus   string    `json:"old_status,omitempty"`
+	NewStatus   string    `json:"new_status,omitempty"`
+}
+
+// WebhookEvent is a webhook notification sent by Statuspage. Incident is set
+// for incident notifications; ComponentUpdate and Component are set for
+// component status notifications.
+type WebhookEvent struct {
+	Meta            WebhookMeta             `json:"meta"`
+	Page            WebhookPage             `json:"page"`
+	Incident        *Incident               `json:"incident,omitempty"`
+	ComponentUpdate *WebhookComponentUpdate `json:"component_update,omitempty"`
+	Component       *Component              `json:"component,omitempty"`
+}
+
+func (e WebhookEvent) String() string {
+	return Stringify(e)
+}
+
+// Type returns the kind of notification the event carries
+func (e *WebhookEvent) Type() string {
+	switch {
+	case e.Incident != nil:
+		return WebhookEventIncident
+	case e.ComponentUpdate != nil:
+		return WebhookEventComponentUpdate
+	default:
+		return WebhookEventUnknown
+	}
+}
+
+// ParseWebhook decodes the webhook notification in the body of r
+func ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("statuspage: webhook method %s, want POST", r.Method)
+	}
+	if r.Body == nil {
+		return nil, fmt.Errorf("statuspage: empty webhook body")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading webhook body: %s", err)
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("error decoding webhook body: %s", err)
+	}
+
+	return &event, nil
+}
+
+// A WebhookVerifier checks that a parsed notification should be trusted.
+// Statuspage does not sign its webhooks, so verification relies on what the
+// subscriber controls: the subscription URL and the expected page.
+type WebhookVerifier func(r *http.Request, event *WebhookEvent) error
+
+// RequireWebhookPage returns a verifier that only accepts notifications for
+// the given page ids
+func RequireWebhookPage(pageIDs ...string) WebhookVerifier {
+	return func(r *http.Request, event *WebhookEvent) error {
+		for _, id := range pageIDs {
+			if event.Page.ID == id {
+				return nil
+			}
+		}
+		return fmt.Errorf("statuspage: webhook for unexpected page %q", event.Page.ID)
+	}
+}
+
+// RequireWebhookToken returns a verifier that only accepts notifications
+// whose URL carries token in the given query parameter. Register the
+// subscriber URL with the token included, e.g. https://example.com/hook?token=...
+func RequireWebhookToken(param, token string) WebhookVerifier {
+	return func(r *http.Request, event *WebhookEvent) error {
+		got := r.URL.Query().Get(param)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return fmt.Errorf("statuspage: invalid webhook token")
+		}
+		return nil
+	}
+}
+
+// WebhookHandler is an http.Handler that parses webhook notifications and
+// dispatches them to the callback for their event type. Events without a
+// matching callback are acknowledged and ignored.
+type WebhookHandler struct {
+	// Verifiers are run in order before any callback.
+	Verifiers []WebhookVerifier
+
+	OnIncident        func(ctx context.Context, event *WebhookEvent) error
+	OnComponentUpdate func(ctx context.Context, event *WebhookEvent) error
+	OnUnknown         func(ctx context.Context, event *WebhookEvent) error
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, err := ParseWebhook(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, verify := range h.Verifiers {
+		if err := verify(r, event); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	var callback func(ctx context.Context, event *WebhookEvent) error
+	switch event.Type() {
+	case WebhookEventIncident:
+		callback = h.OnIncident
+	case WebhookEventComponentUpdate:
+		callback = h.OnComponentUpdate
+	default:
+		callback = h.OnUnknown
+	}
+
+	if callback != nil {
+		if err := callback(r.Context(), event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
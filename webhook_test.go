@@ -0,0 +1,119 @@
+package statuspage_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	statuspage "github.com/isaaclimdc/statuspage-go"
+)
+
+const componentWebhookBody = `{
+	"meta": {"unsubscribe": "http://statustest.flyingkleinbrothers.com:5000/?unsubscribe=j0vqr9kl3513", "documentation": "http://doers.statuspage.io/customer-notifications/webhooks/"},
+	"page": {"id": "j2mfxwj97wnj", "status_indicator": "major", "status_description": "Partial System Outage"},
+	"component_update": {"created_at": "2006-01-02T15:04:05Z", "new_status": "operational", "old_status": "major_outage", "id": "k7730b5v92bv", "component_id": "rb5wq1dczvbm"},
+	"component": {"created_at": "2006-01-02T15:04:05Z", "id": "rb5wq1dczvbm", "name": "Some Component", "status": "operational"}
+}`
+
+const incidentWebhookBody = `{
+	"meta": {"unsubscribe": "u", "documentation": "d"},
+	"page": {"id": "j2mfxwj97wnj", "status_indicator": "critical", "status_description": "Major System Outage"},
+	"incident": {"id": "lbkhbwn21v5q", "name": "Virginia is failing", "status": "identified", "impact": "critical",
+		"incident_updates": [{"id": "drfcwbnpxnr6", "status": "identified", "body": "We found it"}]}
+}`
+
+func newWebhookRequest(body string) *http.Request {
+	return httptest.NewRequest("POST", "/hook?token=secret", strings.NewReader(body))
+}
+
+func TestParseWebhook_componentUpdate(t *testing.T) {
+	event, err := statuspage.ParseWebhook(newWebhookRequest(componentWebhookBody))
+	if err != nil {
+		t.Fatalf("ParseWebhook returned error: %v", err)
+	}
+
+	if got := event.Type(); got != statuspage.WebhookEventComponentUpdate {
+		t.Errorf("WebhookEvent.Type = %q, want %q", got, statuspage.WebhookEventComponentUpdate)
+	}
+
+	want := &statuspage.WebhookComponentUpdate{
+		ID:          "k7730b5v92bv",
+		ComponentID: "rb5wq1dczvbm",
+		CreatedAt:   statuspage.Timestamp{referenceTime},
+		OldStatus:   statuspage.StatusMajorOutage,
+		NewStatus:   statuspage.StatusOperational,
+	}
+	if !reflect.DeepEqual(event.ComponentUpdate, want) {
+		t.Errorf("ParseWebhook component_update = %+v, want %+v", event.ComponentUpdate, want)
+	}
+
+	if event.Component == nil || event.Component.Name != "Some Component" {
+		t.Errorf("ParseWebhook component = %+v, want name %q", event.Component, "Some Component")
+	}
+}
+
+func TestParseWebhook_incident(t *testing.T) {
+	event, err := statuspage.ParseWebhook(newWebhookRequest(incidentWebhookBody))
+	if err != nil {
+		t.Fatalf("ParseWebhook returned error: %v", err)
+	}
+
+	if got := event.Type(); got != statuspage.WebhookEventIncident {
+		t.Errorf("WebhookEvent.Type = %q, want %q", got, statuspage.WebhookEventIncident)
+	}
+	if event.Incident.ID != "lbkhbwn21v5q" || len(event.Incident.IncidentUpdates) != 1 {
+		t.Errorf("ParseWebhook incident = %+v", event.Incident)
+	}
+	if event.Page.StatusIndicator != "critical" {
+		t.Errorf("ParseWebhook page = %+v, want indicator critical", event.Page)
+	}
+}
+
+func TestParseWebhook_invalid(t *testing.T) {
+	if _, err := statuspage.ParseWebhook(httptest.NewRequest("GET", "/hook", nil)); err == nil {
+		t.Error("ParseWebhook with GET expected error")
+	}
+	if _, err := statuspage.ParseWebhook(newWebhookRequest("{")); err == nil {
+		t.Error("ParseWebhook with malformed body expected error")
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var incidents, components int
+	handler := &statuspage.WebhookHandler{
+		Verifiers: []statuspage.WebhookVerifier{
+			statuspage.RequireWebhookPage("j2mfxwj97wnj"),
+			statuspage.RequireWebhookToken("token", "secret"),
+		},
+		OnIncident: func(ctx context.Context, event *statuspage.WebhookEvent) error {
+			incidents++
+			return nil
+		},
+		OnComponentUpdate: func(ctx context.Context, event *statuspage.WebhookEvent) error {
+			components++
+			return nil
+		},
+	}
+
+	for _, body := range []string{incidentWebhookBody, componentWebhookBody} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newWebhookRequest(body))
+		if rec.Code != http.StatusOK {
+			t.Errorf("WebhookHandler status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	}
+
+	if incidents != 1 || components != 1 {
+		t.Errorf("WebhookHandler dispatched %d incidents and %d component updates, want 1 and 1", incidents, components)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/hook?token=wrong", strings.NewReader(incidentWebhookBody))
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("WebhookHandler with bad token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}